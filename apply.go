@@ -0,0 +1,184 @@
+package jsontype
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// Apply copies the present fields of patch onto the matching fields of dst.
+//
+// Patch must be a struct, or a pointer to a struct, containing the Null types of this package.
+// Dst must be a non-nil pointer to a struct. Fields are matched by Go field name or by the
+// name in their json tag. For every field of patch that is Present, the matching field of dst
+// is set to the value if the field is Valid, or to its zero value (nil for pointers) if the
+// field is null. Absent fields leave dst untouched.
+//
+// The value of a patch field must be assignable to the destination field, or to its element
+// type if the destination field is a pointer. If the destination field is itself one of the
+// Null types, its Valid and Present fields are set as well. Struct fields of patch are applied
+// recursively to the matching struct fields of dst; other fields of patch are ignored.
+func Apply(dst, patch any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("jsontype: Apply destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+	pv := reflect.ValueOf(patch)
+	if pv.Kind() == reflect.Pointer && !pv.IsNil() {
+		pv = pv.Elem()
+	}
+	if pv.Kind() != reflect.Struct {
+		return fmt.Errorf("jsontype: Apply patch must be a struct or a pointer to a struct, got %T", patch)
+	}
+	return applyStruct(dv.Elem(), pv, "")
+}
+
+func applyStruct(dst, patch reflect.Value, path string) error {
+	dstFields := typeinfo.Fields(dst.Type(), "json")
+	for _, pf := range typeinfo.Fields(patch.Type(), "json") {
+		pv, _ := typeinfo.FieldByIndex(patch, pf.Index)
+		fpath := appendPointer(path, pf.Name)
+
+		if value, valid, present, ok := typeinfo.NullParts(pv); ok {
+			if !present.Bool() {
+				continue
+			}
+			df, err := destField(dst, dstFields, pf, fpath)
+			if err != nil {
+				return err
+			}
+			if err := applyValue(df, pv, value, valid.Bool()); err != nil {
+				return fmt.Errorf("jsontype: cannot apply %s: %w", fpath, err)
+			}
+			continue
+		}
+
+		if pv.Kind() == reflect.Pointer {
+			if pv.IsNil() {
+				continue
+			}
+			pv = pv.Elem()
+		}
+		if pv.Kind() != reflect.Struct || !hasPresent(pv) {
+			continue
+		}
+		df, err := destField(dst, dstFields, pf, fpath)
+		if err != nil {
+			return err
+		}
+		if df.Kind() == reflect.Pointer && df.Type().Elem().Kind() == reflect.Struct {
+			if df.IsNil() {
+				df.Set(reflect.New(df.Type().Elem()))
+			}
+			df = df.Elem()
+		}
+		if df.Kind() != reflect.Struct {
+			return fmt.Errorf("jsontype: cannot apply %s: %s is not a struct", fpath, df.Type())
+		}
+		if err := applyStruct(df, pv, fpath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchField returns the field of fields matching pf by Go field name or, failing that, by tag
+// name.
+func matchField(fields []typeinfo.Field, pf typeinfo.Field) (typeinfo.Field, bool) {
+	for _, f := range fields {
+		if f.StructField.Name == pf.StructField.Name {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if f.Name == pf.Name {
+			return f, true
+		}
+	}
+	return typeinfo.Field{}, false
+}
+
+// destField returns the field of dst matching pf, allocating the embedded structs it is in.
+func destField(dst reflect.Value, fields []typeinfo.Field, pf typeinfo.Field, path string) (reflect.Value, error) {
+	f, found := matchField(fields, pf)
+	if !found {
+		return reflect.Value{}, fmt.Errorf("jsontype: cannot apply %s: no matching field in %s", path, dst.Type())
+	}
+	df, ok := typeinfo.SettableFieldByIndex(dst, f.Index)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("jsontype: cannot apply %s: embedded pointer to unexported struct in %s", path, dst.Type())
+	}
+	return df, nil
+}
+
+// applyValue sets dst from the Null type patch, whose wrapped value is value.
+func applyValue(dst, patch, value reflect.Value, valid bool) error {
+	if dv, dvalid, dpresent, ok := typeinfo.NullParts(dst); ok {
+		if dst.Type() == patch.Type() {
+			dst.Set(patch)
+			return nil
+		}
+		if valid {
+			if err := assign(dv, value); err != nil {
+				return err
+			}
+		} else {
+			dv.Set(reflect.Zero(dv.Type()))
+		}
+		dvalid.SetBool(valid)
+		dpresent.SetBool(true)
+		return nil
+	}
+	if !valid {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	return assign(dst, value)
+}
+
+// assign sets dst to v, allocating a new value if dst is a pointer to the type of v.
+func assign(dst, v reflect.Value) error {
+	switch {
+	case v.Type().AssignableTo(dst.Type()):
+		dst.Set(v)
+	case convertible(v.Type(), dst.Type()):
+		dst.Set(v.Convert(dst.Type()))
+	case dst.Kind() == reflect.Pointer && (v.Type().AssignableTo(dst.Type().Elem()) || convertible(v.Type(), dst.Type().Elem())):
+		p := reflect.New(dst.Type().Elem())
+		p.Elem().Set(v.Convert(dst.Type().Elem()))
+		dst.Set(p)
+	default:
+		return fmt.Errorf("%s is not assignable to %s", v.Type(), dst.Type())
+	}
+	return nil
+}
+
+// convertible reports whether values of type from can be converted to type to without changing
+// their kind, e.g. from string to a named string type.
+func convertible(from, to reflect.Type) bool {
+	return from.Kind() == to.Kind() && from.ConvertibleTo(to)
+}
+
+// hasPresent reports whether struct v contains a Present field, directly or in nested structs.
+func hasPresent(v reflect.Value) bool {
+	for _, f := range typeinfo.Fields(v.Type(), "json") {
+		fv, _ := typeinfo.FieldByIndex(v, f.Index)
+		if _, _, present, ok := typeinfo.NullParts(fv); ok {
+			if present.Bool() {
+				return true
+			}
+			continue
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && hasPresent(fv) {
+			return true
+		}
+	}
+	return false
+}
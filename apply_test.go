@@ -0,0 +1,211 @@
+package jsontype
+
+import (
+	"reflect"
+	"testing"
+)
+
+type applyAddress struct {
+	Street string
+	City   string
+}
+
+type applyPerson struct {
+	Name     string
+	Nickname *string
+	Age      int
+	Email    string `json:"email"`
+	Address  applyAddress
+	Manager  *applyAddress
+	Score    NullFloat64
+}
+
+type applyAddressPatch struct {
+	City NullString
+}
+
+type applyPersonPatch struct {
+	Name     NullString
+	Nickname Null[string]
+	Age      NullInt
+	Mail     NullString `json:"email"`
+	Address  applyAddressPatch
+	Manager  *applyAddressPatch
+	Score    NullFloat64
+}
+
+// Test the Apply function
+func TestApply(t *testing.T) {
+	nickname := "Johnny"
+	original := applyPerson{
+		Name:     "John",
+		Nickname: &nickname,
+		Age:      42,
+		Email:    "john@example.com",
+		Address:  applyAddress{Street: "Main Street", City: "New York"},
+		Score:    NullFloat64{Float64: 1.5, Valid: true, Present: true},
+	}
+
+	tests := []struct {
+		name      string
+		patch     any
+		expected  func(p *applyPerson)
+		expectErr bool
+	}{
+		{
+			name:     "Absent fields",
+			patch:    applyPersonPatch{},
+			expected: func(p *applyPerson) {},
+		},
+		{
+			name: "Valid fields",
+			patch: applyPersonPatch{
+				Name: NullString{String: "Jane", Valid: true, Present: true},
+				Age:  NullInt{Int: 0, Valid: true, Present: true},
+			},
+			expected: func(p *applyPerson) { p.Name, p.Age = "Jane", 0 },
+		},
+		{
+			name: "Null fields",
+			patch: &applyPersonPatch{
+				Name:     NullString{Present: true},
+				Nickname: Null[string]{Present: true},
+			},
+			expected: func(p *applyPerson) { p.Name, p.Nickname = "", nil },
+		},
+		{
+			name:     "Pointer destination",
+			patch:    applyPersonPatch{Nickname: Null[string]{Value: "J", Valid: true, Present: true}},
+			expected: func(p *applyPerson) { s := "J"; p.Nickname = &s },
+		},
+		{
+			name:     "Matched by json tag",
+			patch:    applyPersonPatch{Mail: NullString{String: "jane@example.com", Valid: true, Present: true}},
+			expected: func(p *applyPerson) { p.Email = "jane@example.com" },
+		},
+		{
+			name:     "Nested struct",
+			patch:    applyPersonPatch{Address: applyAddressPatch{City: NullString{String: "Boston", Valid: true, Present: true}}},
+			expected: func(p *applyPerson) { p.Address.City = "Boston" },
+		},
+		{
+			name:     "Nested struct pointer",
+			patch:    applyPersonPatch{Manager: &applyAddressPatch{City: NullString{String: "Boston", Valid: true, Present: true}}},
+			expected: func(p *applyPerson) { p.Manager = &applyAddress{City: "Boston"} },
+		},
+		{
+			name:     "Nested struct pointer without present fields",
+			patch:    applyPersonPatch{Manager: &applyAddressPatch{}},
+			expected: func(p *applyPerson) {},
+		},
+		{
+			name:     "Null type destination",
+			patch:    applyPersonPatch{Score: NullFloat64{Present: true}},
+			expected: func(p *applyPerson) { p.Score = NullFloat64{Present: true} },
+		},
+		{
+			name: "Type mismatch",
+			patch: struct {
+				Age NullString
+			}{Age: NullString{String: "42", Valid: true, Present: true}},
+			expectErr: true,
+		},
+		{
+			name: "No matching field",
+			patch: struct {
+				Unknown NullString
+			}{Unknown: NullString{Present: true}},
+			expectErr: true,
+		},
+		{
+			name:      "Invalid patch",
+			patch:     "hello",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := original
+			err := Apply(&p, tt.patch)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Apply() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if tt.expectErr {
+				return
+			}
+			expected := original
+			tt.expected(&expected)
+			if !reflect.DeepEqual(p, expected) {
+				t.Errorf("Apply() = %+v, expected %+v", p, expected)
+			}
+		})
+	}
+}
+
+// Test the Apply function with an invalid destination
+func TestApply_InvalidDestination(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  any
+	}{
+		{name: "Nil", dst: nil},
+		{name: "Struct value", dst: applyPerson{}},
+		{name: "Nil pointer", dst: (*applyPerson)(nil)},
+		{name: "Pointer to non-struct", dst: new(int)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Apply(tt.dst, applyPersonPatch{}); err == nil {
+				t.Errorf("Apply() error = nil, expected an error")
+			}
+		})
+	}
+}
+
+// Test that Apply reports the path of the failing field
+func TestApply_ErrorPath(t *testing.T) {
+	var dst struct {
+		Address struct {
+			Zip int `json:"zip"`
+		} `json:"address"`
+	}
+	patch := struct {
+		Address struct {
+			Zip NullString `json:"zip"`
+		} `json:"address"`
+	}{}
+	patch.Address.Zip = NullString{String: "1234AB", Valid: true, Present: true}
+
+	err := Apply(&dst, patch)
+	expected := `jsontype: cannot apply /address/zip: string is not assignable to int`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Apply() error = %v, expected %s", err, expected)
+	}
+}
+
+// Test that Apply allocates nil embedded pointers to structs of the destination
+func TestApply_EmbeddedPointer(t *testing.T) {
+	var e marshalEmbedded
+	if err := Apply(&e, struct{ ID NullInt }{ID: NullInt{Int: 7, Valid: true, Present: true}}); err != nil {
+		t.Errorf("Apply() error = %v", err)
+	} else if e.MarshalBase == nil || e.ID.Int != 7 {
+		t.Errorf("Apply() = %+v, expected an allocated MarshalBase with ID 7", e)
+	}
+}
+
+// Test that Apply sets the destination field hiding an embedded field with the same name
+func TestApply_ShadowedField(t *testing.T) {
+	var dst marshalShadowed
+	patch := struct {
+		ID NullInt `json:"id"`
+	}{ID: NullInt{Int: 5, Valid: true, Present: true}}
+	if err := Apply(&dst, patch); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if dst.ID.Int != 5 || dst.MarshalBase.ID.Present {
+		t.Errorf("Apply() = %+v, expected ID 5 in the outer field", dst)
+	}
+}
@@ -0,0 +1,100 @@
+// Package cbor encodes and decodes structs containing the Null types of package jsontype as CBOR
+// (RFC 8949). CBOR distinguishes undefined from null, which maps onto the Present and Valid fields:
+// an undefined value or a missing map key is absent, null is a present null and any other value is
+// a valid value.
+//
+// Marshal writes the deterministic encoding of RFC 8949, section 4.2.1: integers, lengths and floats
+// use their shortest form, indefinite lengths are not used and map keys are sorted by the bytewise
+// order of their encodings. Structs are encoded as maps with text keys taken from the cbor struct
+// tag, or the Go field name if the tag is missing; fields tagged with cbor:"-" are ignored. Absent
+// fields are omitted, or encoded as undefined with the EncodeUndefined option. Times are encoded as
+// RFC 3339 text strings with tag 0.
+//
+// Unmarshal accepts any well-formed CBOR, including indefinite lengths and non-shortest forms.
+// When decoding into an empty interface, integers become int64, or uint64 if they do not fit, floats
+// become float64, maps become map[any]any, tagged items become Tag, simple values without a Go
+// equivalent become Simple and null and undefined become nil.
+package cbor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Tag is a tagged data item with a tag number other than 0 for times.
+type Tag struct {
+	Number  uint64
+	Content any
+}
+
+// Simple is a simple value other than false, true, null and undefined. Values 20 to 31 cannot be
+// encoded.
+type Simple uint8
+
+// EncodeOption configures Marshal.
+type EncodeOption func(*encoder)
+
+// EncodeUndefined makes Marshal encode absent struct fields as undefined instead of omitting them.
+func EncodeUndefined() EncodeOption {
+	return func(e *encoder) { e.undefined = true }
+}
+
+// Marshal returns the deterministic CBOR encoding of v.
+func Marshal(v any, opts ...EncodeOption) ([]byte, error) {
+	var e encoder
+	for _, opt := range opts {
+		opt(&e)
+	}
+	if err := e.encode(reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+// Unmarshal decodes the CBOR data item in data into the value pointed to by v. Data must contain
+// exactly one data item.
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("cbor: Unmarshal requires a non-nil pointer, got %T", v)
+	}
+	d := decoder{data: data}
+	if err := d.decode(rv.Elem()); err != nil {
+		return err
+	}
+	if d.off < len(d.data) {
+		return errors.New("cbor: unexpected data after top-level data item")
+	}
+	return nil
+}
+
+// The major types of RFC 8949, section 3.1.
+const (
+	majorUint   byte = 0
+	majorNegint byte = 1
+	majorBytes  byte = 2
+	majorText   byte = 3
+	majorArray  byte = 4
+	majorMap    byte = 5
+	majorTag    byte = 6
+	majorSimple byte = 7
+)
+
+// Initial bytes of the simple values and floats.
+const (
+	byteFalse     byte = 0xf4
+	byteTrue      byte = 0xf5
+	byteNull      byte = 0xf6
+	byteUndefined byte = 0xf7
+	byteFloat16   byte = 0xf9
+	byteFloat32   byte = 0xfa
+	byteFloat64   byte = 0xfb
+	byteBreak     byte = 0xff
+)
+
+// Tag numbers for times.
+const (
+	tagTimeString = 0
+	tagTimeEpoch  = 1
+)
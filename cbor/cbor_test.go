@@ -0,0 +1,352 @@
+package cbor
+
+import (
+	"encoding/hex"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mbe81/jsontype"
+)
+
+// Test that the examples of RFC 8949, appendix A, decode and encode to their deterministic encoding
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string // expected is the deterministic encoding if it differs from input
+	}{
+		{name: "0", input: "00"},
+		{name: "1", input: "01"},
+		{name: "10", input: "0a"},
+		{name: "23", input: "17"},
+		{name: "24", input: "1818"},
+		{name: "25", input: "1819"},
+		{name: "100", input: "1864"},
+		{name: "1000", input: "1903e8"},
+		{name: "1000000", input: "1a000f4240"},
+		{name: "1000000000000", input: "1b000000e8d4a51000"},
+		{name: "18446744073709551615", input: "1bffffffffffffffff"},
+		{name: "Bignum", input: "c249010000000000000000"},
+		{name: "Negative bignum", input: "c349010000000000000000"},
+		{name: "-1", input: "20"},
+		{name: "-10", input: "29"},
+		{name: "-100", input: "3863"},
+		{name: "-1000", input: "3903e7"},
+		{name: "0.0", input: "f90000"},
+		{name: "-0.0", input: "f98000"},
+		{name: "1.0", input: "f93c00"},
+		{name: "1.1", input: "fb3ff199999999999a"},
+		{name: "1.5", input: "f93e00"},
+		{name: "65504.0", input: "f97bff"},
+		{name: "100000.0", input: "fa47c35000"},
+		{name: "3.4028234663852886e+38", input: "fa7f7fffff"},
+		{name: "1.0e+300", input: "fb7e37e43c8800759c"},
+		{name: "5.960464477539063e-8", input: "f90001"},
+		{name: "0.00006103515625", input: "f90400"},
+		{name: "-4.0", input: "f9c400"},
+		{name: "-4.1", input: "fbc010666666666666"},
+		{name: "Infinity", input: "f97c00"},
+		{name: "NaN", input: "f97e00"},
+		{name: "-Infinity", input: "f9fc00"},
+		{name: "Infinity float32", input: "fa7f800000", expected: "f97c00"},
+		{name: "NaN float32", input: "fa7fc00000", expected: "f97e00"},
+		{name: "-Infinity float32", input: "faff800000", expected: "f9fc00"},
+		{name: "Infinity float64", input: "fb7ff0000000000000", expected: "f97c00"},
+		{name: "NaN float64", input: "fb7ff8000000000000", expected: "f97e00"},
+		{name: "-Infinity float64", input: "fbfff0000000000000", expected: "f9fc00"},
+		{name: "false", input: "f4"},
+		{name: "true", input: "f5"},
+		{name: "null", input: "f6"},
+		{name: "undefined", input: "f7", expected: "f6"},
+		{name: "simple(16)", input: "f0"},
+		{name: "simple(255)", input: "f8ff"},
+		{name: "Tag 0", input: "c074323031332d30332d32315432303a30343a30305a"},
+		{name: "Tag 1", input: "c11a514b67b0"},
+		{name: "Tag 1 float", input: "c1fb41d452d9ec200000"},
+		{name: "Tag 23", input: "d74401020304"},
+		{name: "Tag 24", input: "d818456449455446"},
+		{name: "Tag 32", input: "d82076687474703a2f2f7777772e6578616d706c652e636f6d"},
+		{name: "Empty byte string", input: "40"},
+		{name: "Byte string", input: "4401020304"},
+		{name: "Empty text string", input: "60"},
+		{name: "a", input: "6161"},
+		{name: "IETF", input: "6449455446"},
+		{name: "Escapes", input: "62225c"},
+		{name: "u00fc", input: "62c3bc"},
+		{name: "u6c34", input: "63e6b0b4"},
+		{name: "Surrogate pair", input: "64f0908591"},
+		{name: "Empty array", input: "80"},
+		{name: "Array", input: "83010203"},
+		{name: "Nested array", input: "8301820203820405"},
+		{name: "Array of 25", input: "98190102030405060708090a0b0c0d0e0f101112131415161718181819"},
+		{name: "Empty map", input: "a0"},
+		{name: "Map", input: "a201020304"},
+		{name: "Map with array", input: "a26161016162820203"},
+		{name: "Array with map", input: "826161a161626163"},
+		{name: "Map of five", input: "a56161614161626142616361436164614461656145"},
+		{name: "Indefinite byte string", input: "5f42010243030405ff", expected: "450102030405"},
+		{name: "Indefinite text string", input: "7f657374726561646d696e67ff", expected: "6973747265616d696e67"},
+		{name: "Indefinite empty array", input: "9fff", expected: "80"},
+		{name: "Indefinite nested arrays", input: "9f018202039f0405ffff", expected: "8301820203820405"},
+		{name: "Indefinite outer array", input: "9f01820203820405ff", expected: "8301820203820405"},
+		{name: "Indefinite last array", input: "83018202039f0405ff", expected: "8301820203820405"},
+		{name: "Indefinite middle array", input: "83019f0203ff820405", expected: "8301820203820405"},
+		{name: "Indefinite array of 25", input: "9f0102030405060708090a0b0c0d0e0f101112131415161718181819ff", expected: "98190102030405060708090a0b0c0d0e0f101112131415161718181819"},
+		{name: "Indefinite map", input: "bf61610161629f0203ffff", expected: "a26161016162820203"},
+		{name: "Indefinite inner map", input: "826161bf61626163ff", expected: "826161a161626163"},
+		{name: "Indefinite map sorted", input: "bf6346756ef563416d7421ff", expected: "a263416d74216346756ef5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expected == "" {
+				tt.expected = tt.input
+			}
+			var v any
+			if err := Unmarshal(mustDecodeHex(tt.input), &v); err != nil {
+				t.Errorf("Unmarshal() error = %v", err)
+				return
+			}
+			result, err := Marshal(v)
+			if err != nil {
+				t.Errorf("Marshal() error = %v", err)
+				return
+			}
+			if hex.EncodeToString(result) != tt.expected {
+				t.Errorf("Marshal() = %x, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the Marshal function with Go values
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     any
+		expected  string
+		expectErr bool
+	}{
+		{name: "Nil", input: nil, expected: "f6"},
+		{name: "Int8", input: int8(-128), expected: "387f"},
+		{name: "Min int64", input: int64(math.MinInt64), expected: "3b7fffffffffffffff"},
+		{name: "Uint16", input: uint16(500), expected: "1901f4"},
+		{name: "Float32", input: float32(0.5), expected: "f93800"},
+		{name: "Float16 subnormal", input: math.Ldexp(3, -24), expected: "f90003"},
+		{name: "Float32 not float16", input: 65536.0, expected: "fa47800000"},
+		{name: "Bytes", input: []byte("ab"), expected: "426162"},
+		{name: "Nil slice", input: []int(nil), expected: "f6"},
+		{name: "Map sorted by encoding", input: map[string]int{"bb": 1, "a": 2, "c": 3}, expected: "a3" + "616102" + "616303" + "62626201"},
+		{name: "Time", input: time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC), expected: "c074323031332d30332d32315432303a30343a30305a"},
+		{name: "Simple 20", input: Simple(20), expectErr: true},
+		{name: "Unsupported", input: make(chan int), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Marshal(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Marshal() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if !tt.expectErr && hex.EncodeToString(result) != tt.expected {
+				t.Errorf("Marshal() = %x, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+type person struct {
+	Name     jsontype.NullString     `cbor:"name"`
+	Age      jsontype.NullInt8       `cbor:"age"`
+	Email    jsontype.NullString     `cbor:"email"`
+	Birthday jsontype.NullTime       `cbor:"birthday"`
+	Tags     jsontype.Null[[]string] `cbor:"tags"`
+	ID       int                     `cbor:"-"`
+}
+
+// Test the encoding of structs containing the Null types
+func TestMarshal_Struct(t *testing.T) {
+	p := person{
+		Name:  jsontype.NullString{String: "Jo", Valid: true, Present: true},
+		Email: jsontype.NullString{Present: true},
+		ID:    1,
+	}
+	tests := []struct {
+		name     string
+		opts     []EncodeOption
+		expected string
+	}{
+		{name: "Absent omitted", expected: "a2" + "646e616d65624a6f" + "65656d61696cf6"},
+		{name: "Absent undefined", opts: []EncodeOption{EncodeUndefined()}, expected: "a5" + "63616765f7" + "646e616d65624a6f" + "6474616773f7" + "65656d61696cf6" + "686269727468646179f7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Marshal(p, tt.opts...)
+			if err != nil {
+				t.Errorf("Marshal() error = %v", err)
+				return
+			}
+			if hex.EncodeToString(result) != tt.expected {
+				t.Errorf("Marshal() = %x, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the decoding of structs containing the Null types
+func TestUnmarshal_Struct(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  person
+		expectErr bool
+	}{
+		{
+			name:     "Missing keys",
+			input:    "a0",
+			expected: person{},
+		},
+		{
+			name:  "Null and undefined",
+			input: "a3" + "646e616d65f6" + "63616765f7" + "6474616773f6",
+			expected: person{
+				Name: jsontype.NullString{Present: true},
+				Tags: jsontype.Null[[]string]{Present: true},
+			},
+		},
+		{
+			name:  "Values",
+			input: "a4" + "646e616d65624a6f" + "63616765181e" + "686269727468646179c11a514b67b0" + "6474616773816161",
+			expected: person{
+				Name:     jsontype.NullString{String: "Jo", Valid: true, Present: true},
+				Age:      jsontype.NullInt8{Int8: 30, Valid: true, Present: true},
+				Birthday: jsontype.NullTime{Time: time.Unix(1363896240, 0), Valid: true, Present: true},
+				Tags:     jsontype.Null[[]string]{Value: []string{"a"}, Valid: true, Present: true},
+			},
+		},
+		{
+			name:     "Unknown keys",
+			input:    "a2" + "63666f6f820102" + "62494401",
+			expected: person{},
+		},
+		{
+			name:      "Overflow",
+			input:     "a16361676519012c",
+			expectErr: true,
+		},
+		{
+			name:      "Type mismatch",
+			input:     "a1646e616d6501",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p person
+			err := Unmarshal(mustDecodeHex(tt.input), &p)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Unmarshal() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if !tt.expectErr && !reflect.DeepEqual(p, tt.expected) {
+				t.Errorf("Unmarshal() = %+v, expected %+v", p, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that Marshal and Unmarshal round-trip structs with EncodeUndefined
+func TestRoundTrip_Struct(t *testing.T) {
+	p := person{
+		Name:     jsontype.NullString{String: "Jo", Valid: true, Present: true},
+		Age:      jsontype.NullInt8{Present: true},
+		Birthday: jsontype.NullTime{Time: time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC), Valid: true, Present: true},
+		Tags:     jsontype.Null[[]string]{Value: []string{"a", "b"}, Valid: true, Present: true},
+	}
+	data, err := Marshal(p, EncodeUndefined())
+	if err != nil {
+		t.Errorf("Marshal() error = %v", err)
+		return
+	}
+	var result person
+	if err := Unmarshal(data, &result); err != nil {
+		t.Errorf("Unmarshal() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(result, p) {
+		t.Errorf("Unmarshal() = %+v, expected %+v", result, p)
+	}
+}
+
+// Test that Unmarshal rejects data that is not well-formed
+func TestUnmarshal_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Empty", input: "", expected: "cbor: unexpected end of data"},
+		{name: "Truncated argument", input: "19", expected: "cbor: unexpected end of data"},
+		{name: "Truncated string", input: "6261", expected: "cbor: unexpected end of data"},
+		{name: "Truncated array", input: "9b00000000ffffffff", expected: "cbor: unexpected end of data"},
+		{name: "Trailing data", input: "0000", expected: "cbor: unexpected data after top-level data item"},
+		{name: "Reserved argument", input: "1c", expected: "cbor: malformed data item"},
+		{name: "Indefinite integer", input: "1f", expected: "cbor: malformed data item"},
+		{name: "Break", input: "ff", expected: "cbor: malformed data item"},
+		{name: "Two-byte simple value below 32", input: "f818", expected: "cbor: malformed data item"},
+		{name: "Mixed chunks", input: "5f6161ff", expected: "cbor: malformed data item"},
+		{name: "Negative overflow", input: "3bffffffffffffffff", expected: "cbor: integer -1-18446744073709551615 overflows int64"},
+		{name: "Nesting depth", input: strings.Repeat("81", maxDepth+1) + "00", expected: "cbor: exceeded maximum nesting depth"},
+		{name: "Array map key", input: "a18000", expected: "cbor: cannot use []interface {} as map key"},
+		{name: "Tagged array map key", input: "a1c58000", expected: "cbor: cannot use cbor.Tag as map key"},
+		{name: "Tagged map map key", input: "a1c1c2a000", expected: "cbor: cannot use cbor.Tag as map key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v any
+			err := Unmarshal(mustDecodeHex(tt.input), &v)
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Unmarshal() error = %v, expected %s", err, tt.expected)
+			}
+		})
+	}
+
+	var m map[any]int
+	if err := Unmarshal(mustDecodeHex("a1c58000"), &m); err == nil || err.Error() != "cbor: cannot use cbor.Tag as map key" {
+		t.Errorf("Unmarshal() error = %v, expected an error for a tagged array key", err)
+	}
+}
+
+// Fuzz Unmarshal with arbitrary input, which must be rejected with an error rather than a panic
+func FuzzUnmarshal(f *testing.F) {
+	for _, seed := range []string{"a18000", "a1c58000", "a1c1c2a000", "bf61610161629f0203ffff", "a3646e616d65644a6f686e63616765f663746167f7", "c074323031332d30332d32315432303a30343a30305a"} {
+		f.Add(mustDecodeHex(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v any
+		if err := Unmarshal(data, &v); err == nil {
+			if _, err := Marshal(v); err != nil {
+				t.Errorf("Marshal() error = %v for %x decoded as %#v", err, data, v)
+			}
+		}
+		var p person
+		_ = Unmarshal(data, &p)
+		var m map[any]any
+		_ = Unmarshal(data, &m)
+	})
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,571 @@
+package cbor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// maxDepth is the maximum nesting depth of arrays, maps and tags accepted by Unmarshal.
+const maxDepth = 1000
+
+var (
+	errUnexpectedEnd = errors.New("cbor: unexpected end of data")
+	errMalformed     = errors.New("cbor: malformed data item")
+	errDepth         = errors.New("cbor: exceeded maximum nesting depth")
+)
+
+type decoder struct {
+	data  []byte
+	off   int
+	depth int
+}
+
+// peek returns the initial byte of the next data item.
+func (d *decoder) peek() (byte, error) {
+	if d.off >= len(d.data) {
+		return 0, errUnexpectedEnd
+	}
+	return d.data[d.off], nil
+}
+
+// next returns the next n bytes.
+func (d *decoder) next(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return nil, errUnexpectedEnd
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// head reads the initial byte and argument of a data item. For indefinite lengths, info is 31 and
+// arg is 0.
+func (d *decoder) head() (major, info byte, arg uint64, err error) {
+	b, err := d.next(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info = b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		b, err := d.next(1 << (info - 24))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		for _, c := range b {
+			arg = arg<<8 | uint64(c)
+		}
+		return major, info, arg, nil
+	case info == 31 && major >= majorBytes && major <= majorMap:
+		return major, info, 0, nil
+	}
+	return 0, 0, 0, errMalformed
+}
+
+// readString reads the content of a byte or text string with the given header.
+func (d *decoder) readString(major, info byte, arg uint64) ([]byte, error) {
+	if info != 31 {
+		return d.next(arg)
+	}
+	b := []byte{}
+	for {
+		c, err := d.peek()
+		if err != nil {
+			return nil, err
+		}
+		if c == byteBreak {
+			d.off++
+			return b, nil
+		}
+		m, i, n, err := d.head()
+		if err != nil {
+			return nil, err
+		}
+		if m != major || i == 31 {
+			return nil, errMalformed
+		}
+		chunk, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, chunk...)
+	}
+}
+
+// each calls f for every element of an array, or every key/value pair of a map, with the given header.
+func (d *decoder) each(info byte, arg uint64, f func() error) error {
+	d.depth++
+	if d.depth > maxDepth {
+		return errDepth
+	}
+	defer func() { d.depth-- }()
+	if info == 31 {
+		for {
+			c, err := d.peek()
+			if err != nil {
+				return err
+			}
+			if c == byteBreak {
+				d.off++
+				return nil
+			}
+			if err := f(); err != nil {
+				return err
+			}
+		}
+	}
+	if arg > uint64(len(d.data)-d.off) {
+		return errUnexpectedEnd
+	}
+	for i := uint64(0); i < arg; i++ {
+		if err := f(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// float returns the value of a float with the given header.
+func float(info byte, arg uint64) float64 {
+	switch info {
+	case 25:
+		return float16(uint16(arg))
+	case 26:
+		return float64(math.Float32frombits(uint32(arg)))
+	}
+	return math.Float64frombits(arg)
+}
+
+// float16 returns the value of the IEEE 754 half-precision bits h.
+func float16(h uint16) float64 {
+	exp, mant := int(h>>10)&0x1f, float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
+
+// describe returns a description of a data item with the given header for error messages.
+func describe(major, info byte) string {
+	switch major {
+	case majorUint:
+		return "unsigned integer"
+	case majorNegint:
+		return "negative integer"
+	case majorBytes:
+		return "byte string"
+	case majorText:
+		return "text string"
+	case majorArray:
+		return "array"
+	case majorMap:
+		return "map"
+	case majorTag:
+		return "tag"
+	}
+	switch info {
+	case 20, 21:
+		return "boolean"
+	case 22:
+		return "null"
+	case 23:
+		return "undefined"
+	case 25, 26, 27:
+		return "float"
+	}
+	return "simple value"
+}
+
+func (d *decoder) decode(v reflect.Value) error {
+	c, err := d.peek()
+	if err != nil {
+		return err
+	}
+	if value, valid, present, ok := typeinfo.NullParts(v); ok {
+		switch c {
+		case byteUndefined:
+			d.off++
+			v.Set(reflect.Zero(v.Type()))
+		case byteNull:
+			d.off++
+			v.Set(reflect.Zero(v.Type()))
+			present.SetBool(true)
+		default:
+			if err := d.decode(value); err != nil {
+				return err
+			}
+			valid.SetBool(true)
+			present.SetBool(true)
+		}
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.NumMethod() > 0 {
+			break
+		}
+		x, err := d.decodeAny()
+		if err != nil {
+			return err
+		}
+		if x == nil {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(reflect.ValueOf(x))
+		}
+		return nil
+	case reflect.Pointer:
+		if c == byteNull || c == byteUndefined {
+			d.off++
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decode(v.Elem())
+	case reflect.Slice, reflect.Map:
+		if c == byteNull || c == byteUndefined {
+			d.off++
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+	}
+	if v.Type() == timeType {
+		return d.decodeTime(v)
+	}
+
+	major, info, arg, err := d.head()
+	if err != nil {
+		return err
+	}
+	typeErr := func() error {
+		return fmt.Errorf("cbor: cannot unmarshal %s into Go value of type %s", describe(major, info), v.Type())
+	}
+	switch major {
+	case majorUint, majorNegint:
+		return setInt(v, major, arg, typeErr)
+	case majorBytes, majorText:
+		b, err := d.readString(major, info, arg)
+		if err != nil {
+			return err
+		}
+		switch {
+		case major == majorText && v.Kind() == reflect.String:
+			v.SetString(string(b))
+		case major == majorBytes && v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+			v.SetBytes(append([]byte{}, b...))
+		default:
+			return typeErr()
+		}
+		return nil
+	case majorArray:
+		return d.decodeArray(v, info, arg, typeErr)
+	case majorMap:
+		return d.decodeMap(v, info, arg, typeErr)
+	case majorTag:
+		// Tags are ignored when decoding into types other than time.Time and empty interfaces.
+		d.depth++
+		defer func() { d.depth-- }()
+		if d.depth > maxDepth {
+			return errDepth
+		}
+		return d.decode(v)
+	}
+	switch info {
+	case 20, 21:
+		if v.Kind() != reflect.Bool {
+			return typeErr()
+		}
+		v.SetBool(info == 21)
+		return nil
+	case 25, 26, 27:
+		f := float(info, arg)
+		if (v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64) || v.OverflowFloat(f) {
+			return typeErr()
+		}
+		v.SetFloat(f)
+		return nil
+	case 24:
+		if arg < 32 {
+			return errMalformed
+		}
+	}
+	return typeErr()
+}
+
+// setInt sets v to the integer with the given major type and argument.
+func setInt(v reflect.Value, major byte, arg uint64, typeErr func() error) error {
+	overflow := func() error {
+		if major == majorNegint {
+			return fmt.Errorf("cbor: integer -1-%d overflows Go value of type %s", arg, v.Type())
+		}
+		return fmt.Errorf("cbor: integer %d overflows Go value of type %s", arg, v.Type())
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if arg > math.MaxInt64 {
+			return overflow()
+		}
+		n := int64(arg)
+		if major == majorNegint {
+			n = -1 - n
+		}
+		if v.OverflowInt(n) {
+			return overflow()
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if major == majorNegint || v.OverflowUint(arg) {
+			return overflow()
+		}
+		v.SetUint(arg)
+	case reflect.Float32, reflect.Float64:
+		f := float64(arg)
+		if major == majorNegint {
+			f = -1 - f
+		}
+		v.SetFloat(f)
+	default:
+		return typeErr()
+	}
+	return nil
+}
+
+func (d *decoder) decodeArray(v reflect.Value, info byte, arg uint64, typeErr func() error) error {
+	switch v.Kind() {
+	case reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), 0, 0)
+		err := d.each(info, arg, func() error {
+			s = reflect.Append(s, reflect.Zero(v.Type().Elem()))
+			return d.decode(s.Index(s.Len() - 1))
+		})
+		if err != nil {
+			return err
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		i := 0
+		err := d.each(info, arg, func() error {
+			if i >= v.Len() {
+				return fmt.Errorf("cbor: array too long for Go value of type %s", v.Type())
+			}
+			i++
+			return d.decode(v.Index(i - 1))
+		})
+		if err != nil {
+			return err
+		}
+		for ; i < v.Len(); i++ {
+			v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+		}
+		return nil
+	}
+	return typeErr()
+}
+
+func (d *decoder) decodeMap(v reflect.Value, info byte, arg uint64, typeErr func() error) error {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		return d.each(info, arg, func() error {
+			k := reflect.New(v.Type().Key()).Elem()
+			if err := d.decode(k); err != nil {
+				return err
+			}
+			if !hashable(k) {
+				return fmt.Errorf("cbor: cannot use %s as map key", reflect.TypeOf(k.Interface()))
+			}
+			val := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decode(val); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, val)
+			return nil
+		})
+	case reflect.Struct:
+		fields := typeinfo.Fields(v.Type(), "cbor")
+		return d.each(info, arg, func() error {
+			k, err := d.decodeAny()
+			if err != nil {
+				return err
+			}
+			for _, f := range fields {
+				if f.Name == k {
+					fv, ok := typeinfo.SettableFieldByIndex(v, f.Index)
+					if !ok {
+						return fmt.Errorf("cbor: cannot set embedded pointer to unexported struct in %s", v.Type())
+					}
+					return d.decode(fv)
+				}
+			}
+			_, err = d.decodeAny()
+			return err
+		})
+	}
+	return typeErr()
+}
+
+// decodeTime decodes a time with tag 0 or 1 into v.
+func (d *decoder) decodeTime(v reflect.Value) error {
+	major, info, arg, err := d.head()
+	if err != nil {
+		return err
+	}
+	if major != majorTag || (arg != tagTimeString && arg != tagTimeEpoch) {
+		return fmt.Errorf("cbor: cannot unmarshal %s into Go value of type %s", describe(major, info), v.Type())
+	}
+	x, err := d.decodeAny()
+	if err != nil {
+		return err
+	}
+	invalid := fmt.Errorf("cbor: invalid content of tag %d", arg)
+	var t time.Time
+	switch x := x.(type) {
+	case string:
+		if arg != tagTimeString {
+			return invalid
+		}
+		if t, err = time.Parse(time.RFC3339Nano, x); err != nil {
+			return fmt.Errorf("cbor: invalid time: %w", err)
+		}
+	case int64:
+		if arg != tagTimeEpoch {
+			return invalid
+		}
+		t = time.Unix(x, 0)
+	case float64:
+		if arg != tagTimeEpoch || math.IsNaN(x) || math.IsInf(x, 0) {
+			return invalid
+		}
+		sec, frac := math.Modf(x)
+		t = time.Unix(int64(sec), int64(frac*1e9))
+	default:
+		return invalid
+	}
+	v.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// hashable reports whether v can be used as a map key. Unlike reflect.Type.Comparable, it checks the
+// dynamic values of interfaces, such as the Content of a Tag, which may be slices or maps.
+func hashable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Interface:
+		return v.IsNil() || hashable(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !hashable(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !hashable(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return v.Type().Comparable()
+}
+
+// decodeAny decodes the next data item into the Go value described in the package documentation.
+func (d *decoder) decodeAny() (any, error) {
+	major, info, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case majorUint:
+		if arg > math.MaxInt64 {
+			return arg, nil
+		}
+		return int64(arg), nil
+	case majorNegint:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor: integer -1-%d overflows int64", arg)
+		}
+		return -1 - int64(arg), nil
+	case majorBytes, majorText:
+		b, err := d.readString(major, info, arg)
+		if err != nil {
+			return nil, err
+		}
+		if major == majorText {
+			return string(b), nil
+		}
+		return append([]byte{}, b...), nil
+	case majorArray:
+		a := []any{}
+		err := d.each(info, arg, func() error {
+			x, err := d.decodeAny()
+			a = append(a, x)
+			return err
+		})
+		return a, err
+	case majorMap:
+		m := map[any]any{}
+		err := d.each(info, arg, func() error {
+			k, err := d.decodeAny()
+			if err != nil {
+				return err
+			}
+			if !hashable(reflect.ValueOf(k)) {
+				return fmt.Errorf("cbor: cannot use %s as map key", reflect.TypeOf(k))
+			}
+			x, err := d.decodeAny()
+			m[k] = x
+			return err
+		})
+		return m, err
+	case majorTag:
+		d.depth++
+		defer func() { d.depth-- }()
+		if d.depth > maxDepth {
+			return nil, errDepth
+		}
+		x, err := d.decodeAny()
+		return Tag{Number: arg, Content: x}, err
+	}
+	switch info {
+	case 20, 21:
+		return info == 21, nil
+	case 22, 23:
+		return nil, nil
+	case 24:
+		if arg < 32 {
+			return nil, errMalformed
+		}
+		return Simple(arg), nil
+	case 25, 26, 27:
+		return float(info, arg), nil
+	}
+	return Simple(info), nil
+}
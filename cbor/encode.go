@@ -0,0 +1,258 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	tagType    = reflect.TypeOf(Tag{})
+	simpleType = reflect.TypeOf(Simple(0))
+)
+
+type encoder struct {
+	bytes.Buffer
+	undefined bool
+}
+
+// head writes the initial byte and argument of a data item with the shortest encoding of n.
+func (e *encoder) head(major byte, n uint64) {
+	major <<= 5
+	switch {
+	case n < 24:
+		e.WriteByte(major | byte(n))
+	case n <= math.MaxUint8:
+		e.Write([]byte{major | 24, byte(n)})
+	case n <= math.MaxUint16:
+		e.WriteByte(major | 25)
+		e.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	case n <= math.MaxUint32:
+		e.WriteByte(major | 26)
+		e.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	default:
+		e.WriteByte(major | 27)
+		e.Write(binary.BigEndian.AppendUint64(nil, n))
+	}
+}
+
+func (e *encoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		e.WriteByte(byteNull)
+		return nil
+	}
+	if value, valid, present, ok := typeinfo.NullParts(v); ok {
+		switch {
+		case !present.Bool():
+			e.WriteByte(byteUndefined)
+		case !valid.Bool():
+			e.WriteByte(byteNull)
+		default:
+			return e.encode(value)
+		}
+		return nil
+	}
+	switch v.Type() {
+	case timeType:
+		e.head(majorTag, tagTimeString)
+		s := v.Interface().(time.Time).Format(time.RFC3339Nano)
+		e.head(majorText, uint64(len(s)))
+		e.WriteString(s)
+		return nil
+	case tagType:
+		t := v.Interface().(Tag)
+		e.head(majorTag, t.Number)
+		return e.encode(reflect.ValueOf(t.Content))
+	case simpleType:
+		n := v.Uint()
+		if n >= 20 && n < 32 {
+			return fmt.Errorf("cbor: cannot encode simple value %d", n)
+		}
+		e.head(majorSimple, n)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.WriteByte(byteTrue)
+		} else {
+			e.WriteByte(byteFalse)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := v.Int(); n < 0 {
+			e.head(majorNegint, uint64(^n))
+		} else {
+			e.head(majorUint, uint64(n))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.head(majorUint, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.encodeFloat(v.Float())
+	case reflect.String:
+		e.head(majorText, uint64(v.Len()))
+		e.WriteString(v.String())
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			e.WriteByte(byteNull)
+			return nil
+		}
+		return e.encode(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			e.WriteByte(byteNull)
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			e.head(majorBytes, uint64(v.Len()))
+			e.Write(v.Bytes())
+			return nil
+		}
+		return e.encodeArray(v)
+	case reflect.Array:
+		return e.encodeArray(v)
+	case reflect.Map:
+		if v.IsNil() {
+			e.WriteByte(byteNull)
+			return nil
+		}
+		return e.encodeMap(v)
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	default:
+		return fmt.Errorf("cbor: unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// encodeFloat writes f in the shortest of the float16, float32 and float64 forms that preserves
+// its value, with NaN written as the float16 quiet NaN 0x7e00.
+func (e *encoder) encodeFloat(f float64) {
+	if math.IsNaN(f) {
+		e.Write([]byte{byteFloat16, 0x7e, 0x00})
+		return
+	}
+	if h, ok := float16Bits(f); ok {
+		e.WriteByte(byteFloat16)
+		e.Write(binary.BigEndian.AppendUint16(nil, h))
+		return
+	}
+	if float64(float32(f)) == f {
+		e.WriteByte(byteFloat32)
+		e.Write(binary.BigEndian.AppendUint32(nil, math.Float32bits(float32(f))))
+		return
+	}
+	e.WriteByte(byteFloat64)
+	e.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(f)))
+}
+
+// float16Bits returns the IEEE 754 half-precision bits of f and whether f is exactly representable
+// in half precision. F must not be NaN.
+func float16Bits(f float64) (uint16, bool) {
+	var sign uint16
+	if math.Signbit(f) {
+		sign = 0x8000
+		f = -f
+	}
+	switch {
+	case f == 0:
+		return sign, true
+	case math.IsInf(f, 0):
+		return sign | 0x7c00, true
+	}
+	frac, exp := math.Frexp(f) // f = frac × 2^exp with frac in [0.5, 1)
+	exp--
+	if exp > 15 {
+		return 0, false
+	}
+	if exp >= -14 {
+		mant := (frac*2 - 1) * 1024
+		if mant != math.Trunc(mant) {
+			return 0, false
+		}
+		return sign | uint16(exp+15)<<10 | uint16(mant), true
+	}
+	mant := f * (1 << 24)
+	if mant != math.Trunc(mant) {
+		return 0, false
+	}
+	return sign | uint16(mant), true
+}
+
+func (e *encoder) encodeArray(v reflect.Value) error {
+	e.head(majorArray, uint64(v.Len()))
+	for i := 0; i < v.Len(); i++ {
+		if err := e.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodeMap(v reflect.Value) error {
+	pairs := make([][2][]byte, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k, err := e.encodeItem(iter.Key())
+		if err != nil {
+			return err
+		}
+		val, err := e.encodeItem(iter.Value())
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, [2][]byte{k, val})
+	}
+	e.writePairs(pairs)
+	return nil
+}
+
+func (e *encoder) encodeStruct(v reflect.Value) error {
+	var pairs [][2][]byte
+	for _, f := range typeinfo.Fields(v.Type(), "cbor") {
+		fv, ok := typeinfo.FieldByIndex(v, f.Index)
+		if !ok {
+			continue
+		}
+		if _, _, present, ok := typeinfo.NullParts(fv); ok && !present.Bool() && !e.undefined {
+			continue
+		}
+		k, err := e.encodeItem(reflect.ValueOf(f.Name))
+		if err != nil {
+			return err
+		}
+		val, err := e.encodeItem(fv)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, [2][]byte{k, val})
+	}
+	e.writePairs(pairs)
+	return nil
+}
+
+// encodeItem returns the encoding of v with the options of e.
+func (e *encoder) encodeItem(v reflect.Value) ([]byte, error) {
+	item := encoder{undefined: e.undefined}
+	if err := item.encode(v); err != nil {
+		return nil, err
+	}
+	return item.Bytes(), nil
+}
+
+// writePairs writes a map of the encoded key/value pairs, sorted by the bytewise order of their keys.
+func (e *encoder) writePairs(pairs [][2][]byte) {
+	sort.Slice(pairs, func(i, j int) bool { return bytes.Compare(pairs[i][0], pairs[j][0]) < 0 })
+	e.head(majorMap, uint64(len(pairs)))
+	for _, p := range pairs {
+		e.Write(p[0])
+		e.Write(p[1])
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsontypePath is the import path of package jsontype.
+const jsontypePath = "github.com/mbe81/jsontype"
+
+// field is a field of a struct type for which a patch field is generated.
+type field struct {
+	Name        string   // Name is the Go field name
+	JSONName    string   // JSONName is the name in the json tag, or the Go field name
+	Tag         string   // Tag contains the json and jsontype tags of the field
+	Type        string   // Type is the value type of the patch field
+	Pointer     bool     // Pointer is set if the field is a pointer to Type
+	Nilable     bool     // Nilable is set if the field is a slice or a map
+	Equal       string   // Equal is the function comparing slices or maps, e.g. slices.Equal
+	EqualMethod bool     // EqualMethod is set if Type has a method Equal(Type) bool, like time.Time
+	Rules       []string // Rules are the rules of the jsontype tag
+}
+
+// generator writes the patch types of a package.
+type generator struct {
+	buf     bytes.Buffer
+	pkg     *types.Package
+	imports map[string]string // imports maps import paths to package names
+}
+
+// generate returns the formatted source of the patch types for the struct types named types in the
+// package in dir, ignoring the file named output.
+func generate(dir, output string, typeNames []string) ([]byte, error) {
+	pkg, err := loadPackage(dir, output)
+	if err != nil {
+		return nil, err
+	}
+	g := generator{pkg: pkg, imports: map[string]string{jsontypePath: "jsontype"}}
+	var body bytes.Buffer
+	for _, name := range typeNames {
+		g.buf.Reset()
+		if err := g.generateType(strings.TrimSpace(name)); err != nil {
+			return nil, err
+		}
+		body.Write(g.buf.Bytes())
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by jsontype-gen -type %s; DO NOT EDIT.\n\n", strings.Join(typeNames, ","))
+	fmt.Fprintf(&src, "package %s\n\nimport (\n", pkg.Name())
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if isStd(paths[i]) != isStd(paths[j]) {
+			return isStd(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	for i, path := range paths {
+		// Standard library packages come first, separated by a blank line from the others.
+		if i > 0 && isStd(paths[i-1]) && !isStd(path) {
+			src.WriteByte('\n')
+		}
+		fmt.Fprintf(&src, "\t%s\n", strconv.Quote(path))
+	}
+	src.WriteString(")\n")
+	src.Write(body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+// isStd reports whether path is the import path of a standard library package.
+func isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// loadPackage parses and type-checks the non-test Go files in dir, except the file named output.
+func loadPackage(dir, output string) (*types.Package, error) {
+	ctx := build.Default
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range matches {
+		name := filepath.Base(path)
+		if name == output || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if ok, err := ctx.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check(files[0].Name.Name, fset, files, nil)
+}
+
+// qualifier returns the name used for pkg in the generated file and records its import.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	g.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+func (g *generator) generateType(name string) error {
+	obj := g.pkg.Scope().Lookup(name)
+	if obj == nil {
+		return fmt.Errorf("type %s not found in package %s", name, g.pkg.Name())
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if _, isType := obj.(*types.TypeName); !isType || !ok {
+		return fmt.Errorf("%s is not a struct type", name)
+	}
+
+	var fields []field
+	for i := 0; i < st.NumFields(); i++ {
+		f, err := g.field(name, st.Field(i), st.Tag(i))
+		if err != nil {
+			return err
+		}
+		if f != nil {
+			fields = append(fields, *f)
+		}
+	}
+	g.writePatchType(name, fields)
+	g.writeApply(name, fields)
+	g.writeDiff(name, fields)
+	g.writeValidate(name, fields)
+	return nil
+}
+
+// field returns the patch field for the struct field v with tag, or nil if v is skipped.
+func (g *generator) field(typeName string, v *types.Var, tag string) (*field, error) {
+	if !v.Exported() || v.Embedded() {
+		return nil, nil
+	}
+	st := reflect.StructTag(tag)
+	jsonTag, hasJSON := st.Lookup("json")
+	if jsonTag == "-" {
+		return nil, nil
+	}
+	f := field{Name: v.Name(), JSONName: v.Name()}
+	if name, _, _ := strings.Cut(jsonTag, ","); name != "" {
+		f.JSONName = name
+	}
+	var tags []string
+	if hasJSON {
+		tags = append(tags, "json:"+strconv.Quote(jsonTag))
+	}
+	if rules, ok := st.Lookup("jsontype"); ok {
+		tags = append(tags, "jsontype:"+strconv.Quote(rules))
+		for _, rule := range strings.Split(rules, ",") {
+			switch rule = strings.TrimSpace(rule); rule {
+			case "required", "notnull", "forbidden":
+				f.Rules = append(f.Rules, rule)
+			default:
+				return nil, fmt.Errorf("unknown rule %q in jsontype tag of %s.%s", rule, typeName, v.Name())
+			}
+		}
+	}
+	if len(tags) > 0 {
+		f.Tag = "`" + strings.Join(tags, " ") + "`"
+	}
+
+	t := v.Type()
+	notComparable := fmt.Errorf("field %s.%s of type %s cannot be compared", typeName, v.Name(), types.TypeString(t, g.qualifier))
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		f.Pointer, f.EqualMethod = true, hasEqualMethod(u.Elem())
+		if !f.EqualMethod && !types.Comparable(u.Elem()) {
+			return nil, notComparable
+		}
+		t = u.Elem()
+	case *types.Slice:
+		if !types.Comparable(u.Elem()) {
+			return nil, notComparable
+		}
+		f.Nilable, f.Equal = true, g.qualifier(types.NewPackage("slices", "slices"))+".Equal"
+	case *types.Map:
+		if !types.Comparable(u.Elem()) {
+			return nil, notComparable
+		}
+		f.Nilable, f.Equal = true, g.qualifier(types.NewPackage("maps", "maps"))+".Equal"
+	default:
+		f.EqualMethod = hasEqualMethod(t)
+		if !f.EqualMethod && !types.Comparable(t) {
+			return nil, notComparable
+		}
+	}
+	f.Type = types.TypeString(t, g.qualifier)
+	return &f, nil
+}
+
+// hasEqualMethod reports whether t has a method Equal(t) bool.
+func hasEqualMethod(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, nil, "Equal")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == 1 && types.Identical(sig.Params().At(0).Type(), t) &&
+		sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
+func (g *generator) writePatchType(name string, fields []field) {
+	fmt.Fprintf(&g.buf, "\n// %sPatch is a patch for %s. Fields that are absent leave %s unchanged.\n", name, name, name)
+	fmt.Fprintf(&g.buf, "type %sPatch struct {\n", name)
+	for _, f := range fields {
+		fmt.Fprintf(&g.buf, "\t%s jsontype.Null[%s] %s\n", f.Name, f.Type, f.Tag)
+	}
+	g.buf.WriteString("}\n")
+}
+
+func (g *generator) writeApply(name string, fields []field) {
+	fmt.Fprintf(&g.buf, "\n// Apply sets the fields of dst that are present in patch to their value, or to their zero value\n// if they are null.\n")
+	fmt.Fprintf(&g.buf, "func (patch %sPatch) Apply(dst *%s) {\n", name, name)
+	for _, f := range fields {
+		fmt.Fprintf(&g.buf, "\tif patch.%s.Present {\n", f.Name)
+		switch {
+		case f.Pointer:
+			fmt.Fprintf(&g.buf, "\t\tif patch.%[1]s.Valid {\n\t\t\tv := patch.%[1]s.Value\n\t\t\tdst.%[1]s = &v\n\t\t} else {\n\t\t\tdst.%[1]s = nil\n\t\t}\n", f.Name)
+		case f.Nilable:
+			fmt.Fprintf(&g.buf, "\t\tif patch.%[1]s.Valid {\n\t\t\tdst.%[1]s = patch.%[1]s.Value\n\t\t} else {\n\t\t\tdst.%[1]s = nil\n\t\t}\n", f.Name)
+		default:
+			fmt.Fprintf(&g.buf, "\t\tif patch.%[1]s.Valid {\n\t\t\tdst.%[1]s = patch.%[1]s.Value\n\t\t} else {\n\t\t\tvar zero %[2]s\n\t\t\tdst.%[1]s = zero\n\t\t}\n", f.Name, f.Type)
+		}
+		g.buf.WriteString("\t}\n")
+	}
+	g.buf.WriteString("}\n")
+}
+
+func (g *generator) writeDiff(name string, fields []field) {
+	fmt.Fprintf(&g.buf, "\n// Diff sets patch to the changes between from and to: fields that differ are set to their value\n// in to, or to null if that is nil, and all other fields are absent.\n")
+	fmt.Fprintf(&g.buf, "func (patch *%sPatch) Diff(from, to %s) {\n", name, name)
+	fmt.Fprintf(&g.buf, "\t*patch = %sPatch{}\n", name)
+	for _, f := range fields {
+		value := fmt.Sprintf("jsontype.Null[%s]{Value: to.%s, Valid: true, Present: true}", f.Type, f.Name)
+		null := fmt.Sprintf("jsontype.Null[%s]{Present: true}", f.Type)
+		switch {
+		case f.Pointer:
+			changed := fmt.Sprintf("*from.%[1]s != *to.%[1]s", f.Name)
+			if f.EqualMethod {
+				changed = fmt.Sprintf("!from.%[1]s.Equal(*to.%[1]s)", f.Name)
+			}
+			fmt.Fprintf(&g.buf, "\tif (from.%[1]s == nil) != (to.%[1]s == nil) || (to.%[1]s != nil && %[2]s) {\n", f.Name, changed)
+			fmt.Fprintf(&g.buf, "\t\tif to.%s == nil {\n\t\t\tpatch.%s = %s\n\t\t} else {\n", f.Name, f.Name, null)
+			fmt.Fprintf(&g.buf, "\t\t\tpatch.%s = jsontype.Null[%s]{Value: *to.%s, Valid: true, Present: true}\n\t\t}\n\t}\n", f.Name, f.Type, f.Name)
+		case f.Nilable:
+			fmt.Fprintf(&g.buf, "\tif (from.%[1]s == nil) != (to.%[1]s == nil) || !%[2]s(from.%[1]s, to.%[1]s) {\n", f.Name, f.Equal)
+			fmt.Fprintf(&g.buf, "\t\tif to.%s == nil {\n\t\t\tpatch.%s = %s\n\t\t} else {\n", f.Name, f.Name, null)
+			fmt.Fprintf(&g.buf, "\t\t\tpatch.%s = %s\n\t\t}\n\t}\n", f.Name, value)
+		case f.EqualMethod:
+			fmt.Fprintf(&g.buf, "\tif !from.%[1]s.Equal(to.%[1]s) {\n\t\tpatch.%[1]s = %[2]s\n\t}\n", f.Name, value)
+		default:
+			fmt.Fprintf(&g.buf, "\tif from.%[1]s != to.%[1]s {\n\t\tpatch.%[1]s = %[2]s\n\t}\n", f.Name, value)
+		}
+	}
+	g.buf.WriteString("}\n")
+}
+
+func (g *generator) writeValidate(name string, fields []field) {
+	fmt.Fprintf(&g.buf, "\n// Validate checks the fields of patch against the rules in their jsontype tags, like\n// jsontype.Validate.\n")
+	fmt.Fprintf(&g.buf, "func (patch %sPatch) Validate() error {\n", name)
+	if !hasRules(fields) {
+		g.buf.WriteString("\treturn nil\n}\n")
+		return
+	}
+	g.buf.WriteString("\tvar errs jsontype.Errors\n")
+	for _, f := range fields {
+		path := strconv.Quote("/" + strings.ReplaceAll(strings.ReplaceAll(f.JSONName, "~", "~0"), "/", "~1"))
+		for _, rule := range f.Rules {
+			var cond string
+			switch rule {
+			case "required":
+				cond = fmt.Sprintf("!patch.%s.Present", f.Name)
+			case "notnull":
+				cond = fmt.Sprintf("patch.%[1]s.Present && !patch.%[1]s.Valid", f.Name)
+			case "forbidden":
+				cond = fmt.Sprintf("patch.%s.Present", f.Name)
+			}
+			fmt.Fprintf(&g.buf, "\tif %s {\n\t\terrs = append(errs, &jsontype.ValidationError{Path: %s, Rule: %q})\n\t}\n", cond, path, rule)
+		}
+	}
+	g.buf.WriteString("\tif len(errs) > 0 {\n\t\treturn errs\n\t}\n\treturn nil\n}\n")
+}
+
+// hasRules reports whether any of fields has a jsontype tag rule.
+func hasRules(fields []field) bool {
+	for _, f := range fields {
+		if len(f.Rules) > 0 {
+			return true
+		}
+	}
+	return false
+}
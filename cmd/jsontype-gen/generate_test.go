@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test that the generated code of the example package is up to date
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	expected, err := os.ReadFile(filepath.Join(dir, "person_patch.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := generate(dir, "person_patch.go", []string{"Person", "Address"})
+	if err != nil {
+		t.Errorf("generate() error = %v", err)
+		return
+	}
+	if string(result) != string(expected) {
+		t.Errorf("generate() = %s, expected %s", result, expected)
+	}
+}
+
+// Test that the Null types generated in package jsontype are up to date
+func TestGenerateNull(t *testing.T) {
+	names := strings.Split("NullInt8,NullInt16,NullInt32,NullInt64,NullUint,NullUint8,NullUint16,NullUint32,NullUint64", ",")
+	for file, jsonv2 := range map[string]bool{"ints_gen.go": false, "ints_jsonv2_gen.go": true} {
+		expected, err := os.ReadFile(filepath.Join("..", "..", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := generateNull(names, jsonv2)
+		if err != nil {
+			t.Errorf("generateNull() error = %v", err)
+			return
+		}
+		if string(result) != string(expected) {
+			t.Errorf("generateNull() = %s, expected %s", result, expected)
+		}
+	}
+
+	if _, err := generateNull([]string{"NullBool"}, false); err == nil || err.Error() != "unsupported Null type NullBool" {
+		t.Errorf("generateNull() error = %v, expected unsupported Null type NullBool", err)
+	}
+}
+
+// Test the errors of generate
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		typeName string
+		expected string
+	}{
+		{
+			name:     "Unknown type",
+			src:      "type T struct{ A int }",
+			typeName: "U",
+			expected: "type U not found in package p",
+		},
+		{
+			name:     "Not a struct",
+			src:      "type T int",
+			typeName: "T",
+			expected: "T is not a struct type",
+		},
+		{
+			name:     "Not comparable",
+			src:      "type T struct{ A [][]int }",
+			typeName: "T",
+			expected: "field T.A of type [][]int cannot be compared",
+		},
+		{
+			name:     "Unknown rule",
+			src:      "type T struct{ A int `jsontype:\"optional\"` }",
+			typeName: "T",
+			expected: `unknown rule "optional" in jsontype tag of T.A`,
+		},
+		{
+			name:     "Type error",
+			src:      "type T struct{ A undefined }",
+			typeName: "T",
+			expected: "undefined: undefined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte("package p\n\n"+tt.src+"\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := generate(dir, "t_patch.go", []string{tt.typeName})
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("generate() error = %v, expected %s", err, tt.expected)
+			}
+		})
+	}
+}
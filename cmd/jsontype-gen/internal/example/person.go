@@ -0,0 +1,28 @@
+// Package example contains a struct with the patch type generated by jsontype-gen, to test the
+// generated code.
+package example
+
+import "time"
+
+//go:generate go run github.com/mbe81/jsontype/cmd/jsontype-gen -type Person,Address
+
+// Person is a domain struct.
+type Person struct {
+	ID         int64             `json:"id" jsontype:"forbidden"`
+	Name       string            `json:"name" jsontype:"required,notnull"`
+	Nickname   *string           `json:"nickname"`
+	Birthday   time.Time         `json:"birthday"`
+	Updated    *time.Time        `json:"updated"`
+	Tags       []string          `json:"tags"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Address    Address           `json:"address"`
+	Note       string
+	Internal   string `json:"-"`
+	unexported int
+}
+
+// Address is a domain struct without tags.
+type Address struct {
+	Street string
+	City   string
+}
@@ -0,0 +1,212 @@
+// Code generated by jsontype-gen -type Person,Address; DO NOT EDIT.
+
+package example
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/mbe81/jsontype"
+)
+
+// PersonPatch is a patch for Person. Fields that are absent leave Person unchanged.
+type PersonPatch struct {
+	ID       jsontype.Null[int64]             `json:"id" jsontype:"forbidden"`
+	Name     jsontype.Null[string]            `json:"name" jsontype:"required,notnull"`
+	Nickname jsontype.Null[string]            `json:"nickname"`
+	Birthday jsontype.Null[time.Time]         `json:"birthday"`
+	Updated  jsontype.Null[time.Time]         `json:"updated"`
+	Tags     jsontype.Null[[]string]          `json:"tags"`
+	Labels   jsontype.Null[map[string]string] `json:"labels,omitempty"`
+	Address  jsontype.Null[Address]           `json:"address"`
+	Note     jsontype.Null[string]
+}
+
+// Apply sets the fields of dst that are present in patch to their value, or to their zero value
+// if they are null.
+func (patch PersonPatch) Apply(dst *Person) {
+	if patch.ID.Present {
+		if patch.ID.Valid {
+			dst.ID = patch.ID.Value
+		} else {
+			var zero int64
+			dst.ID = zero
+		}
+	}
+	if patch.Name.Present {
+		if patch.Name.Valid {
+			dst.Name = patch.Name.Value
+		} else {
+			var zero string
+			dst.Name = zero
+		}
+	}
+	if patch.Nickname.Present {
+		if patch.Nickname.Valid {
+			v := patch.Nickname.Value
+			dst.Nickname = &v
+		} else {
+			dst.Nickname = nil
+		}
+	}
+	if patch.Birthday.Present {
+		if patch.Birthday.Valid {
+			dst.Birthday = patch.Birthday.Value
+		} else {
+			var zero time.Time
+			dst.Birthday = zero
+		}
+	}
+	if patch.Updated.Present {
+		if patch.Updated.Valid {
+			v := patch.Updated.Value
+			dst.Updated = &v
+		} else {
+			dst.Updated = nil
+		}
+	}
+	if patch.Tags.Present {
+		if patch.Tags.Valid {
+			dst.Tags = patch.Tags.Value
+		} else {
+			dst.Tags = nil
+		}
+	}
+	if patch.Labels.Present {
+		if patch.Labels.Valid {
+			dst.Labels = patch.Labels.Value
+		} else {
+			dst.Labels = nil
+		}
+	}
+	if patch.Address.Present {
+		if patch.Address.Valid {
+			dst.Address = patch.Address.Value
+		} else {
+			var zero Address
+			dst.Address = zero
+		}
+	}
+	if patch.Note.Present {
+		if patch.Note.Valid {
+			dst.Note = patch.Note.Value
+		} else {
+			var zero string
+			dst.Note = zero
+		}
+	}
+}
+
+// Diff sets patch to the changes between from and to: fields that differ are set to their value
+// in to, or to null if that is nil, and all other fields are absent.
+func (patch *PersonPatch) Diff(from, to Person) {
+	*patch = PersonPatch{}
+	if from.ID != to.ID {
+		patch.ID = jsontype.Null[int64]{Value: to.ID, Valid: true, Present: true}
+	}
+	if from.Name != to.Name {
+		patch.Name = jsontype.Null[string]{Value: to.Name, Valid: true, Present: true}
+	}
+	if (from.Nickname == nil) != (to.Nickname == nil) || (to.Nickname != nil && *from.Nickname != *to.Nickname) {
+		if to.Nickname == nil {
+			patch.Nickname = jsontype.Null[string]{Present: true}
+		} else {
+			patch.Nickname = jsontype.Null[string]{Value: *to.Nickname, Valid: true, Present: true}
+		}
+	}
+	if !from.Birthday.Equal(to.Birthday) {
+		patch.Birthday = jsontype.Null[time.Time]{Value: to.Birthday, Valid: true, Present: true}
+	}
+	if (from.Updated == nil) != (to.Updated == nil) || (to.Updated != nil && !from.Updated.Equal(*to.Updated)) {
+		if to.Updated == nil {
+			patch.Updated = jsontype.Null[time.Time]{Present: true}
+		} else {
+			patch.Updated = jsontype.Null[time.Time]{Value: *to.Updated, Valid: true, Present: true}
+		}
+	}
+	if (from.Tags == nil) != (to.Tags == nil) || !slices.Equal(from.Tags, to.Tags) {
+		if to.Tags == nil {
+			patch.Tags = jsontype.Null[[]string]{Present: true}
+		} else {
+			patch.Tags = jsontype.Null[[]string]{Value: to.Tags, Valid: true, Present: true}
+		}
+	}
+	if (from.Labels == nil) != (to.Labels == nil) || !maps.Equal(from.Labels, to.Labels) {
+		if to.Labels == nil {
+			patch.Labels = jsontype.Null[map[string]string]{Present: true}
+		} else {
+			patch.Labels = jsontype.Null[map[string]string]{Value: to.Labels, Valid: true, Present: true}
+		}
+	}
+	if from.Address != to.Address {
+		patch.Address = jsontype.Null[Address]{Value: to.Address, Valid: true, Present: true}
+	}
+	if from.Note != to.Note {
+		patch.Note = jsontype.Null[string]{Value: to.Note, Valid: true, Present: true}
+	}
+}
+
+// Validate checks the fields of patch against the rules in their jsontype tags, like
+// jsontype.Validate.
+func (patch PersonPatch) Validate() error {
+	var errs jsontype.Errors
+	if patch.ID.Present {
+		errs = append(errs, &jsontype.ValidationError{Path: "/id", Rule: "forbidden"})
+	}
+	if !patch.Name.Present {
+		errs = append(errs, &jsontype.ValidationError{Path: "/name", Rule: "required"})
+	}
+	if patch.Name.Present && !patch.Name.Valid {
+		errs = append(errs, &jsontype.ValidationError{Path: "/name", Rule: "notnull"})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// AddressPatch is a patch for Address. Fields that are absent leave Address unchanged.
+type AddressPatch struct {
+	Street jsontype.Null[string]
+	City   jsontype.Null[string]
+}
+
+// Apply sets the fields of dst that are present in patch to their value, or to their zero value
+// if they are null.
+func (patch AddressPatch) Apply(dst *Address) {
+	if patch.Street.Present {
+		if patch.Street.Valid {
+			dst.Street = patch.Street.Value
+		} else {
+			var zero string
+			dst.Street = zero
+		}
+	}
+	if patch.City.Present {
+		if patch.City.Valid {
+			dst.City = patch.City.Value
+		} else {
+			var zero string
+			dst.City = zero
+		}
+	}
+}
+
+// Diff sets patch to the changes between from and to: fields that differ are set to their value
+// in to, or to null if that is nil, and all other fields are absent.
+func (patch *AddressPatch) Diff(from, to Address) {
+	*patch = AddressPatch{}
+	if from.Street != to.Street {
+		patch.Street = jsontype.Null[string]{Value: to.Street, Valid: true, Present: true}
+	}
+	if from.City != to.City {
+		patch.City = jsontype.Null[string]{Value: to.City, Valid: true, Present: true}
+	}
+}
+
+// Validate checks the fields of patch against the rules in their jsontype tags, like
+// jsontype.Validate.
+func (patch AddressPatch) Validate() error {
+	return nil
+}
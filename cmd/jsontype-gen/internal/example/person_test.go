@@ -0,0 +1,132 @@
+package example
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mbe81/jsontype"
+)
+
+func newPerson() Person {
+	nick := "Jo"
+	updated := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	return Person{
+		ID:       1,
+		Name:     "John",
+		Nickname: &nick,
+		Birthday: time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC),
+		Updated:  &updated,
+		Tags:     []string{"a"},
+		Address:  Address{Street: "Main Street", City: "Boston"},
+		Note:     "note",
+	}
+}
+
+// Test the generated Apply method
+func TestPersonPatch_Apply(t *testing.T) {
+	var patch PersonPatch
+	if err := json.Unmarshal([]byte(`{"name":"Jane","nickname":null,"tags":["b","c"],"address":{"City":"Denver"},"labels":{"x":"y"}}`), &patch); err != nil {
+		t.Fatal(err)
+	}
+	p := newPerson()
+	patch.Apply(&p)
+
+	expected := newPerson()
+	expected.Name = "Jane"
+	expected.Nickname = nil
+	expected.Tags = []string{"b", "c"}
+	expected.Labels = map[string]string{"x": "y"}
+	expected.Address = Address{City: "Denver"}
+	if !reflect.DeepEqual(p, expected) {
+		t.Errorf("Apply() = %+v, expected %+v", p, expected)
+	}
+}
+
+// Test the generated Diff method
+func TestPersonPatch_Diff(t *testing.T) {
+	from := newPerson()
+	to := newPerson()
+	nick := "Johnny"
+	to.Nickname = &nick
+	to.Tags = nil
+	to.Birthday = from.Birthday.In(time.FixedZone("CET", 3600))
+	updated := from.Updated.In(time.FixedZone("CET", 3600))
+	to.Updated = &updated
+	to.Note = "changed"
+
+	var patch PersonPatch
+	patch.Diff(from, to)
+	expected := PersonPatch{
+		Nickname: jsontype.Null[string]{Value: "Johnny", Valid: true, Present: true},
+		Tags:     jsontype.Null[[]string]{Present: true},
+		Note:     jsontype.Null[string]{Value: "changed", Valid: true, Present: true},
+	}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Errorf("Diff() = %+v, expected %+v", patch, expected)
+	}
+
+	patch.Apply(&from)
+	if !reflect.DeepEqual(from.Nickname, to.Nickname) || from.Tags != nil || from.Note != to.Note {
+		t.Errorf("Apply(Diff()) = %+v, expected %+v", from, to)
+	}
+}
+
+// Test the generated Validate method
+func TestPersonPatch_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    PersonPatch
+		expected []jsontype.ValidationError
+	}{
+		{
+			name:  "Valid",
+			patch: PersonPatch{Name: jsontype.Null[string]{Value: "Jane", Valid: true, Present: true}},
+		},
+		{
+			name:     "Required",
+			patch:    PersonPatch{},
+			expected: []jsontype.ValidationError{{Path: "/name", Rule: "required"}},
+		},
+		{
+			name: "Forbidden and not null",
+			patch: PersonPatch{
+				ID:   jsontype.Null[int64]{Value: 1, Valid: true, Present: true},
+				Name: jsontype.Null[string]{Present: true},
+			},
+			expected: []jsontype.ValidationError{{Path: "/id", Rule: "forbidden"}, {Path: "/name", Rule: "notnull"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.patch.Validate()
+			var errs jsontype.Errors
+			if len(tt.expected) == 0 {
+				if err != nil {
+					t.Errorf("Validate() error = %v, expected nil", err)
+				}
+				return
+			}
+			if !errors.As(err, &errs) || len(errs) != len(tt.expected) {
+				t.Errorf("Validate() error = %v, expected %v", err, tt.expected)
+				return
+			}
+			for i, e := range errs {
+				if ve, ok := e.(*jsontype.ValidationError); !ok || *ve != tt.expected[i] {
+					t.Errorf("Validate() error %d = %v, expected %v", i, e, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// Test that the generated Validate method agrees with jsontype.Validate
+func TestPersonPatch_ValidateReflection(t *testing.T) {
+	patch := PersonPatch{ID: jsontype.Null[int64]{Present: true}}
+	if a, b := patch.Validate(), jsontype.Validate(patch); a == nil || b == nil || a.Error() != b.Error() {
+		t.Errorf("Validate() = %v, expected %v", a, b)
+	}
+}
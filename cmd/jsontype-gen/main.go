@@ -0,0 +1,83 @@
+// Command jsontype-gen generates patch types for structs, for use with go:generate.
+//
+// For every struct type T given by the -type flag, jsontype-gen writes a type TPatch with a
+// jsontype.Null field for every exported field of T, together with methods that work without
+// reflection:
+//
+//	func (patch TPatch) Apply(dst *T)
+//	func (patch *TPatch) Diff(from, to T)
+//	func (patch TPatch) Validate() error
+//
+// Apply sets the fields of dst that are present in patch, Diff sets patch to the fields that
+// changed between from and to, and Validate checks the rules in the jsontype tags of the fields of
+// T, like jsontype.Validate. The fields of TPatch keep the json and jsontype tags of the fields of T.
+// A field of pointer type *E becomes a jsontype.Null[E] field whose null value sets the pointer to
+// nil. Embedded fields and fields tagged with json:"-" are skipped.
+//
+// Usage:
+//
+//	//go:generate jsontype-gen -type Person
+//
+// The flags are:
+//
+//	-type
+//		comma-separated list of struct type names; required unless -null is given
+//	-output
+//		output file name; default <first type in lower case>_patch.go, required with -null
+//	-dir
+//		directory of the package containing the types; default the current directory
+//	-null
+//		comma-separated list of the concrete integer Null types of package jsontype, such as
+//		NullInt8, to generate with all their methods instead of patch types
+//	-jsonv2
+//		with -null, generate the encoding/json/v2 methods of the types instead
+//
+// The -null flag is used by package jsontype itself: the generated code relies on unexported
+// helpers of the package, so it cannot be used elsewhere.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	output := flag.String("output", "", "output file name; default <first type in lower case>_patch.go")
+	dir := flag.String("dir", ".", "directory of the package containing the types")
+	nullNames := flag.String("null", "", "comma-separated list of integer Null types of package jsontype to generate")
+	jsonv2 := flag.Bool("jsonv2", false, "with -null, generate the encoding/json/v2 methods")
+	flag.Parse()
+	if *typeNames == "" && (*nullNames == "" || *output == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var (
+		filename string
+		src      []byte
+		err      error
+	)
+	if *nullNames != "" {
+		filename = filepath.Join(*dir, *output)
+		src, err = generateNull(strings.Split(*nullNames, ","), *jsonv2)
+	} else {
+		types := strings.Split(*typeNames, ",")
+		if *output == "" {
+			*output = strings.ToLower(types[0]) + "_patch.go"
+		}
+		filename = filepath.Join(*dir, *output)
+		src, err = generate(*dir, filepath.Base(filename), types)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "jsontype-gen:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filename, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "jsontype-gen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+// nullType is a concrete Null type of package jsontype holding an integer, whose methods are
+// generated with the -null flag.
+type nullType struct {
+	Name    string // Name is the name of the type, e.g. NullInt8
+	Field   string // Field is the name of the value field, e.g. Int8
+	Type    string // Type is the type of the value, e.g. int8
+	Article string // Article is the indefinite article for Type
+	Recv    string // Recv is the name of the receiver
+	Signed  bool   // Signed is set for the signed integer types
+	Bits    string // Bits is the size of Type in bits, or strconv.IntSize
+}
+
+// nullTypes are the Null types that can be generated, by name.
+var nullTypes = map[string]nullType{}
+
+func init() {
+	for _, t := range []struct {
+		name, bits string
+	}{
+		{"Int8", "8"}, {"Int16", "16"}, {"Int32", "32"}, {"Int64", "64"},
+		{"Uint", "strconv.IntSize"}, {"Uint8", "8"}, {"Uint16", "16"}, {"Uint32", "32"}, {"Uint64", "64"},
+	} {
+		nt := nullType{Name: "Null" + t.name, Field: t.name, Type: strings.ToLower(t.name), Bits: t.bits}
+		nt.Signed = strings.HasPrefix(t.name, "Int")
+		nt.Article, nt.Recv = "a", "nu"
+		if nt.Signed {
+			nt.Article, nt.Recv = "an", "ni"
+		}
+		nullTypes[nt.Name] = nt
+	}
+}
+
+// Conv returns expr, an int64 or uint64, converted to the value type of t.
+func (t nullType) Conv(expr string) string {
+	if t.Type == "int64" || t.Type == "uint64" {
+		return expr
+	}
+	return t.Type + "(" + expr + ")"
+}
+
+// Wide returns expr, a value of t, converted to int64 or uint64.
+func (t nullType) Wide(expr string) string {
+	switch {
+	case t.Type == "int64" || t.Type == "uint64":
+		return expr
+	case t.Signed:
+		return "int64(" + expr + ")"
+	}
+	return "uint64(" + expr + ")"
+}
+
+// FitsInt64 reports whether all values of t fit in an int64, the integer type of driver.Value.
+func (t nullType) FitsInt64() bool {
+	return t.Type != "uint" && t.Type != "uint64"
+}
+
+// generateNull returns the formatted source of the methods of the Null types named typeNames for
+// package jsontype, or of their encoding/json/v2 methods if jsonv2 is set.
+func generateNull(typeNames []string, jsonv2 bool) ([]byte, error) {
+	var types []nullType
+	intSize := false
+	for _, name := range typeNames {
+		t, ok := nullTypes[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported Null type %s", name)
+		}
+		types = append(types, t)
+		intSize = intSize || t.Bits == "strconv.IntSize"
+	}
+
+	var src bytes.Buffer
+	if jsonv2 {
+		fmt.Fprintf(&src, "// Code generated by jsontype-gen -null %s -jsonv2; DO NOT EDIT.\n\n", strings.Join(typeNames, ","))
+		src.WriteString("//go:build goexperiment.jsonv2 && go1.27\n\npackage jsontype\n\nimport (\n\t\"encoding/json/jsontext\"\n")
+		if intSize {
+			src.WriteString("\t\"strconv\"\n")
+		}
+		src.WriteString(")\n")
+	} else {
+		fmt.Fprintf(&src, "// Code generated by jsontype-gen -null %s; DO NOT EDIT.\n\n", strings.Join(typeNames, ","))
+		src.WriteString("package jsontype\n\nimport (\n\t\"bytes\"\n\t\"database/sql/driver\"\n\t\"encoding/json\"\n\t\"strconv\"\n)\n")
+	}
+	tmpl := nullTemplate
+	if jsonv2 {
+		tmpl = nullJSONV2Template
+	}
+	if err := tmpl.Execute(&src, types); err != nil {
+		return nil, err
+	}
+	return format.Source(src.Bytes())
+}
+
+var nullTemplate = template.Must(template.New("null").Parse(`
+{{- range .}}
+// {{.Name}} represents {{.Article}} {{.Type}} that may be null or may be absent.
+// {{.Name}} implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for {{.Type}} or that are not integers are rejected.
+type {{.Name}} struct {
+	{{.Field}} {{.Type}}
+	Valid bool // Valid is true if {{.Field}} is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func ({{.Recv}} *{{.Name}}) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, {{.Recv}}) {
+		return nil
+	}
+	n, err := {{if .Signed}}unmarshalInt{{else}}unmarshalUint{{end}}(data, &{{.Recv}}.{{.Field}}, {{.Bits}})
+	if err != nil {
+		return err
+	}
+	{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = {{.Conv "n"}}, true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func ({{.Recv}} {{.Name}}) MarshalJSON() ([]byte, error) {
+	if !{{.Recv}}.Present || !{{.Recv}}.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal({{.Recv}}.{{.Field}})
+}
+
+// IsZero reports whether {{.Recv}} is absent, so that the omitzero tag option omits absent fields.
+func ({{.Recv}} {{.Name}}) IsZero() bool {
+	return !{{.Recv}}.Present
+}
+
+// Get returns the value of {{.Recv}} and true if {{.Recv}} is set, or the zero value and false otherwise.
+func ({{.Recv}} {{.Name}}) Get() ({{.Type}}, bool) {
+	if {{.Recv}}.State() != StateSet {
+		return 0, false
+	}
+	return {{.Recv}}.{{.Field}}, true
+}
+
+// State returns the state of {{.Recv}}.
+func ({{.Recv}} {{.Name}}) State() State {
+	return stateOf({{.Recv}}.Valid, {{.Recv}}.Present)
+}
+
+// Set sets {{.Recv}} to v.
+func ({{.Recv}} *{{.Name}}) Set(v {{.Type}}) {
+	{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = v, true, true
+}
+
+// SetNull sets {{.Recv}} to null.
+func ({{.Recv}} *{{.Name}}) SetNull() {
+	{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = 0, false, true
+}
+
+// Unset makes {{.Recv}} absent.
+func ({{.Recv}} *{{.Name}}) Unset() {
+	*{{.Recv}} = {{.Name}}{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func ({{.Recv}} *{{.Name}}) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = 0, false, true
+		return nil
+	}
+	n, err := {{if .Signed}}strconv.ParseInt{{else}}strconv.ParseUint{{end}}(string(text), 10, {{.Bits}})
+	if err != nil {
+		return err
+	}
+	{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = {{.Conv "n"}}, true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func ({{.Recv}} {{.Name}}) MarshalText() ([]byte, error) {
+	if !{{.Recv}}.Present || !{{.Recv}}.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte({{if .Signed}}strconv.FormatInt{{else}}strconv.FormatUint{{end}}({{.Wide (printf "%s.%s" .Recv .Field)}}, 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func ({{.Recv}} *{{.Name}}) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *{{.Type}}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = 0, false, true
+		return nil
+	}
+	{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func ({{.Recv}} {{.Name}}) MarshalYAML() (any, error) {
+	if !{{.Recv}}.Present || !{{.Recv}}.Valid {
+		return nil, nil
+	}
+	return {{.Recv}}.{{.Field}}, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func ({{.Recv}} *{{.Name}}) Scan(src any) error {
+	v, valid, err := scan[{{.Type}}](src)
+	if err != nil {
+		return err
+	}
+	{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func ({{.Recv}} {{.Name}}) Value() (driver.Value, error) {
+	if !{{.Recv}}.Present || !{{.Recv}}.Valid {
+		return nil, nil
+	}
+	{{- if .FitsInt64}}
+	return int64({{.Recv}}.{{.Field}}), nil
+	{{- else}}
+	return driver.DefaultParameterConverter.ConvertValue({{.Recv}}.{{.Field}})
+	{{- end}}
+}
+{{end}}`))
+
+var nullJSONV2Template = template.Must(template.New("nulljsonv2").Parse(`
+{{- range .}}
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func ({{.Recv}} *{{.Name}}) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = 0, false, true
+		return nil
+	}
+	if ok, err := decodeQuoted(dec, {{.Recv}}, "integer"); ok {
+		return err
+	}
+	n, err := {{if .Signed}}decodeInt{{else}}decodeUint{{end}}(dec, &{{.Recv}}.{{.Field}}, {{.Bits}})
+	if err != nil {
+		return err
+	}
+	{{.Recv}}.{{.Field}}, {{.Recv}}.Valid, {{.Recv}}.Present = {{.Conv "n"}}, true, true
+	return nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func ({{.Recv}} {{.Name}}) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !{{.Recv}}.Present || !{{.Recv}}.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken({{if .Signed}}jsontext.Int{{else}}jsontext.Uint{{end}}({{.Wide (printf "%s.%s" .Recv .Field)}}))
+}
+{{end}}`))
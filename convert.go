@@ -0,0 +1,164 @@
+package jsontype
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Nullable is implemented by all Null types holding a value of type T, such as NullString and
+// Null[string]. It is used by the conversion functions to accept any of them.
+type Nullable[T any] interface {
+	Get() (T, bool)
+	State() State
+}
+
+// FromPtr returns a value of the Null type N holding a T, such as NullString or Null[string], that
+// is set to *p, or null if p is nil:
+//
+//	name := jsontype.FromPtr[jsontype.NullString](p.Name)
+func FromPtr[N any, T any, PN interface {
+	*N
+	Set(T)
+	SetNull()
+}](p *T) N {
+	var n N
+	if p == nil {
+		PN(&n).SetNull()
+	} else {
+		PN(&n).Set(*p)
+	}
+	return n
+}
+
+// ToPtr returns a pointer to a copy of the value of n, or nil if n is null or absent.
+func ToPtr[T any](n Nullable[T]) *T {
+	if v, ok := n.Get(); ok {
+		return &v
+	}
+	return nil
+}
+
+// fromSQL returns a value of the Null type N holding a T that is set to v, or null if valid is false.
+func fromSQL[N any, T any, PN interface {
+	*N
+	Set(T)
+	SetNull()
+}](v T, valid bool) N {
+	var n N
+	if valid {
+		PN(&n).Set(v)
+	} else {
+		PN(&n).SetNull()
+	}
+	return n
+}
+
+// FromSQLBool returns the sql.NullBool s as a NullBool, which is null if s is not valid.
+func FromSQLBool(s sql.NullBool) NullBool {
+	return fromSQL[NullBool](s.Bool, s.Valid)
+}
+
+// FromSQLByte returns the sql.NullByte s as a NullUint8, which is null if s is not valid.
+func FromSQLByte(s sql.NullByte) NullUint8 {
+	return fromSQL[NullUint8](s.Byte, s.Valid)
+}
+
+// FromSQLFloat64 returns the sql.NullFloat64 s as a NullFloat64, which is null if s is not valid.
+func FromSQLFloat64(s sql.NullFloat64) NullFloat64 {
+	return fromSQL[NullFloat64](s.Float64, s.Valid)
+}
+
+// FromSQLInt16 returns the sql.NullInt16 s as a NullInt16, which is null if s is not valid.
+func FromSQLInt16(s sql.NullInt16) NullInt16 {
+	return fromSQL[NullInt16](s.Int16, s.Valid)
+}
+
+// FromSQLInt32 returns the sql.NullInt32 s as a NullInt32, which is null if s is not valid.
+func FromSQLInt32(s sql.NullInt32) NullInt32 {
+	return fromSQL[NullInt32](s.Int32, s.Valid)
+}
+
+// FromSQLInt64 returns the sql.NullInt64 s as a NullInt64, which is null if s is not valid.
+func FromSQLInt64(s sql.NullInt64) NullInt64 {
+	return fromSQL[NullInt64](s.Int64, s.Valid)
+}
+
+// FromSQLString returns the sql.NullString s as a NullString, which is null if s is not valid.
+func FromSQLString(s sql.NullString) NullString {
+	return fromSQL[NullString](s.String, s.Valid)
+}
+
+// FromSQLTime returns the sql.NullTime s as a NullTime, which is null if s is not valid.
+func FromSQLTime(s sql.NullTime) NullTime {
+	return fromSQL[NullTime](s.Time, s.Valid)
+}
+
+// Convert returns n as a value of the Null type N holding the same type T, keeping its state. It
+// converts between the concrete and the generic Null types, for example from NullInt to Null[int]
+// and back:
+//
+//	age := jsontype.Convert[jsontype.Null[int]](p.Age)
+func Convert[N any, T any, PN interface {
+	*N
+	Set(T)
+	SetNull()
+}](n Nullable[T]) N {
+	var c N
+	switch n.State() {
+	case StateSet:
+		v, _ := n.Get()
+		PN(&c).Set(v)
+	case StateNull:
+		PN(&c).SetNull()
+	}
+	return c
+}
+
+// Get returns the value of nb and true if nb is set, or the zero value and false otherwise.
+func (nb NullBool) Get() (bool, bool) {
+	if nb.State() != StateSet {
+		return false, false
+	}
+	return nb.Bool, true
+}
+
+// Get returns the value of nf and true if nf is set, or the zero value and false otherwise.
+func (nf NullFloat64) Get() (float64, bool) {
+	if nf.State() != StateSet {
+		return 0, false
+	}
+	return nf.Float64, true
+}
+
+// Get returns the value of ni and true if ni is set, or the zero value and false otherwise.
+func (ni NullInt) Get() (int, bool) {
+	if ni.State() != StateSet {
+		return 0, false
+	}
+	return ni.Int, true
+}
+
+// Get returns the value of ns and true if ns is set, or the zero value and false otherwise.
+func (ns NullString) Get() (string, bool) {
+	if ns.State() != StateSet {
+		return "", false
+	}
+	return ns.String, true
+}
+
+// Get returns the value of nt and true if nt is set, or the zero value and false otherwise.
+func (nt NullTime) Get() (time.Time, bool) {
+	if nt.State() != StateSet {
+		return time.Time{}, false
+	}
+	return nt.Time, true
+}
+
+// Get returns the value of nt and true if nt is set, or the zero value and false otherwise.
+func (nt Null[T]) Get() (T, bool) {
+	if nt.State() != StateSet {
+		var zero T
+		return zero, false
+	}
+	return nt.Value, true
+}
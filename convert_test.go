@@ -0,0 +1,106 @@
+package jsontype
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test the Get method of the Null types
+func TestGet(t *testing.T) {
+	now := time.Date(2024, 01, 01, 00, 00, 00, 00, time.UTC)
+	tests := []struct {
+		name     string
+		get      func() (any, bool)
+		expected any
+		ok       bool
+	}{
+		{name: "NullBool set", get: func() (any, bool) { return NullBool{Bool: true, Valid: true, Present: true}.Get() }, expected: true, ok: true},
+		{name: "NullInt8 set", get: func() (any, bool) { return NullInt8{Int8: -8, Valid: true, Present: true}.Get() }, expected: int8(-8), ok: true},
+		{name: "NullUint64 set", get: func() (any, bool) { return NullUint64{Uint64: 64, Valid: true, Present: true}.Get() }, expected: uint64(64), ok: true},
+		{name: "NullTime set", get: func() (any, bool) { return NullTime{Time: now, Valid: true, Present: true}.Get() }, expected: now, ok: true},
+		{name: "NullString null", get: func() (any, bool) { return NullString{String: "stale", Present: true}.Get() }, expected: ""},
+		{name: "NullFloat64 valid but absent", get: func() (any, bool) { return NullFloat64{Float64: 1.5, Valid: true}.Get() }, expected: 0.0},
+		{name: "Null[T] set", get: func() (any, bool) { return Value("John").Get() }, expected: "John", ok: true},
+		{name: "Null[T] absent", get: func() (any, bool) { return Absent[int]().Get() }, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := tt.get()
+			if result != tt.expected || ok != tt.ok {
+				t.Errorf("Get() = %v, %t, expected %v, %t", result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+// Test the FromPtr and ToPtr functions
+func TestFromPtrToPtr(t *testing.T) {
+	name := "John"
+	if result := FromPtr[NullString](&name); result != (NullString{String: "John", Valid: true, Present: true}) {
+		t.Errorf("FromPtr() = %+v, expected John", result)
+	}
+	if result := FromPtr[Null[string]]((*string)(nil)); result != NullValue[string]() {
+		t.Errorf("FromPtr() = %+v, expected null", result)
+	}
+
+	if p := ToPtr[string](NullString{String: "John", Valid: true, Present: true}); p == nil || *p != "John" {
+		t.Errorf("ToPtr() = %v, expected a pointer to John", p)
+	}
+	if p := ToPtr[int](NullValue[int]()); p != nil {
+		t.Errorf("ToPtr() = %v, expected nil", p)
+	}
+	if p := ToPtr[time.Time](NullTime{}); p != nil {
+		t.Errorf("ToPtr() = %v, expected nil", p)
+	}
+}
+
+// Test the FromSQL* functions
+func TestFromSQLTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   any
+		expected any
+	}{
+		{name: "NullBool", result: FromSQLBool(sql.NullBool{Bool: true, Valid: true}), expected: NullBool{Bool: true, Valid: true, Present: true}},
+		{name: "NullByte", result: FromSQLByte(sql.NullByte{Byte: 8, Valid: true}), expected: NullUint8{Uint8: 8, Valid: true, Present: true}},
+		{name: "NullFloat64", result: FromSQLFloat64(sql.NullFloat64{Float64: 1.5}), expected: NullFloat64{Present: true}},
+		{name: "NullInt16", result: FromSQLInt16(sql.NullInt16{Int16: 16, Valid: true}), expected: NullInt16{Int16: 16, Valid: true, Present: true}},
+		{name: "NullInt32", result: FromSQLInt32(sql.NullInt32{Int32: 32, Valid: true}), expected: NullInt32{Int32: 32, Valid: true, Present: true}},
+		{name: "NullInt64", result: FromSQLInt64(sql.NullInt64{}), expected: NullInt64{Present: true}},
+		{name: "NullString", result: FromSQLString(sql.NullString{String: "John", Valid: true}), expected: NullString{String: "John", Valid: true, Present: true}},
+		{name: "NullTime", result: FromSQLTime(sql.NullTime{Time: time.Unix(0, 0), Valid: true}), expected: NullTime{Time: time.Unix(0, 0), Valid: true, Present: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result != tt.expected {
+				t.Errorf("FromSQL*() = %+v, expected %+v", tt.result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the Convert function
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		convert  func() any
+		expected any
+	}{
+		{name: "NullInt to Null[int]", convert: func() any { return Convert[Null[int], int](NullInt{Int: 7, Valid: true, Present: true}) }, expected: Value(7)},
+		{name: "Null[int] to NullInt", convert: func() any { return Convert[NullInt, int](NullValue[int]()) }, expected: NullInt{Present: true}},
+		{name: "Absent", convert: func() any { return Convert[Null[bool], bool](NullBool{}) }, expected: Absent[bool]()},
+		{name: "Null[string] to NullString", convert: func() any { return Convert[NullString, string](Value("x")) }, expected: NullString{String: "x", Valid: true, Present: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.convert(); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Convert() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Package csvrecord reads and writes CSV records as structs containing the Null types of package
+// jsontype, distinguishing empty cells, null cells and missing columns.
+//
+// Columns are matched to the Null fields of a struct by the name in the csv struct tag, or the Go
+// field name if the tag is missing, and the header in the first record of the file. Fields tagged
+// with csv:"-" and fields that are not Null types are ignored. A cell equal to a null token (\N by
+// default) is a present null, any other cell is parsed as the value of its field, so that an empty
+// cell is an empty string for NullString, and fields without a column in the header are absent.
+//
+// Values are parsed and formatted with their UnmarshalText and MarshalText methods if they have
+// them, such as time.Time, which uses RFC 3339, and with package strconv otherwise.
+package csvrecord
+
+import (
+	"encoding/csv"
+	"fmt"
+	"reflect"
+
+	"github.com/mbe81/jsontype"
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// DefaultNullToken is the default text of a null cell, as written by MySQL and PostgreSQL.
+const DefaultNullToken = `\N`
+
+// Option configures a Reader or Writer.
+type Option func(*options)
+
+type options struct {
+	nullTokens []string
+}
+
+// NullTokens sets the texts of null cells, instead of DefaultNullToken. The Reader decodes cells
+// equal to any of the tokens as null, and the Writer writes null values as the first token, or as
+// an empty cell if no token is given.
+func NullTokens(tokens ...string) Option {
+	return func(o *options) { o.nullTokens = tokens }
+}
+
+func newOptions(opts []Option) options {
+	o := options{nullTokens: []string{DefaultNullToken}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// isNull reports whether cell is one of the null tokens.
+func (o *options) isNull(cell string) bool {
+	for _, token := range o.nullTokens {
+		if cell == token {
+			return true
+		}
+	}
+	return false
+}
+
+// CellError describes a cell that cannot be parsed into its field.
+type CellError struct {
+	Line   int    // Line is the line of the cell, starting at 1
+	Column string // Column is the name of the column in the header
+	Value  string // Value is the text of the cell
+	Err    error  // Err is the error returned by the parser
+}
+
+// Error implements the error interface.
+func (e *CellError) Error() string {
+	return fmt.Sprintf("csvrecord: cannot parse %q in column %q on line %d: %v", e.Value, e.Column, e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// A Reader reads records from a CSV file with a header into structs.
+type Reader struct {
+	r       *csv.Reader
+	opts    options
+	header  []string
+	columns map[string]int // columns maps the names in the header to their index
+}
+
+// NewReader returns a Reader reading from r, which may be configured before the first call to
+// Read, for example to set its Comma.
+func NewReader(r *csv.Reader, opts ...Option) *Reader {
+	return &Reader{r: r, opts: newOptions(opts)}
+}
+
+// Header returns the header of the file, reading it if it has not been read yet.
+func (r *Reader) Header() ([]string, error) {
+	if r.header != nil {
+		return r.header, nil
+	}
+	header, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	r.header = append([]string(nil), header...)
+	r.columns = make(map[string]int, len(header))
+	for i, name := range r.header {
+		if _, ok := r.columns[name]; !ok {
+			r.columns[name] = i
+		}
+	}
+	return r.header, nil
+}
+
+// Read reads the next record into the Null fields of the struct pointed to by dst. It returns
+// io.EOF if there are no more records.
+//
+// Read sets all Null fields of dst: fields without a column are absent, fields whose cell is a
+// null token are null and the other fields are set to the value parsed from their cell. Read
+// decodes all cells it can and returns a jsontype.Errors list with a *CellError for every cell that
+// cannot be parsed; the fields of those cells are left absent. Errors of the csv.Reader, such as a
+// *csv.ParseError, are returned as is.
+func (r *Reader) Read(dst any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("csvrecord: Read destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+	dv = dv.Elem()
+	if _, err := r.Header(); err != nil {
+		return err
+	}
+	record, err := r.r.Read()
+	if err != nil {
+		return err
+	}
+
+	var errs jsontype.Errors
+	for _, f := range typeinfo.Fields(dv.Type(), "csv") {
+		if !typeinfo.IsNull(f.StructField.Type) {
+			continue
+		}
+		i, found := r.columns[f.Name]
+		if !found || i >= len(record) {
+			if fv, ok := typeinfo.FieldByIndex(dv, f.Index); ok {
+				fv.Set(reflect.Zero(fv.Type()))
+			}
+			continue
+		}
+		fv, ok := typeinfo.SettableFieldByIndex(dv, f.Index)
+		if !ok {
+			return fmt.Errorf("csvrecord: cannot set embedded pointer to unexported struct in %s", dv.Type())
+		}
+		value, valid, present, _ := typeinfo.NullParts(fv)
+		fv.Set(reflect.Zero(fv.Type()))
+		cell := record[i]
+		if r.opts.isNull(cell) {
+			present.SetBool(true)
+			continue
+		}
+		if err := typeinfo.ParseText(value, cell); err != nil {
+			line, _ := r.r.FieldPos(i)
+			errs = append(errs, &CellError{Line: line, Column: f.Name, Value: cell, Err: err})
+			fv.Set(reflect.Zero(fv.Type()))
+			continue
+		}
+		valid.SetBool(true)
+		present.SetBool(true)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// A Writer writes structs as records to a CSV file with a header.
+type Writer struct {
+	w      *csv.Writer
+	opts   options
+	typ    reflect.Type     // typ is the type of the structs written
+	header []typeinfo.Field // header are the fields of the columns
+}
+
+// NewWriter returns a Writer writing to w. The header is written with the first record.
+func NewWriter(w *csv.Writer, opts ...Option) *Writer {
+	return &Writer{w: w, opts: newOptions(opts)}
+}
+
+// Write writes the Null fields of the struct src, or the struct src points to, as a record. The
+// first call writes the header as well, with the names of the Null fields of src in the order of
+// the fields; all later calls must pass a struct of the same type.
+//
+// Null values are written as the first null token and set values as their text. Absent values are
+// written as empty cells, as a column cannot be missing from a single record, so that they are read
+// back as empty strings for NullString, and as errors for most other types.
+//
+// Like csv.Writer, Write buffers its output; call Flush to write it to the underlying writer.
+func (w *Writer) Write(src any) error {
+	sv := reflect.ValueOf(src)
+	for sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("csvrecord: Write requires a struct or a pointer to a struct, got %T", src)
+	}
+	if w.typ == nil {
+		w.typ = sv.Type()
+		var names []string
+		for _, f := range typeinfo.Fields(sv.Type(), "csv") {
+			if typeinfo.IsNull(f.StructField.Type) {
+				w.header = append(w.header, f)
+				names = append(names, f.Name)
+			}
+		}
+		if err := w.w.Write(names); err != nil {
+			return err
+		}
+	} else if sv.Type() != w.typ {
+		return fmt.Errorf("csvrecord: Write requires a %s like the first record, got %T", w.typ, src)
+	}
+
+	record := make([]string, len(w.header))
+	for i, f := range w.header {
+		fv, _ := typeinfo.FieldByIndex(sv, f.Index)
+		value, valid, present, _ := typeinfo.NullParts(fv)
+		switch {
+		case !present.Bool():
+			// absent values are written as empty cells
+		case !valid.Bool():
+			if len(w.opts.nullTokens) > 0 {
+				record[i] = w.opts.nullTokens[0]
+			}
+		default:
+			text, err := typeinfo.FormatText(value)
+			if err != nil {
+				return fmt.Errorf("csvrecord: column %q: %w", f.Name, err)
+			}
+			record[i] = text
+		}
+	}
+	return w.w.Write(record)
+}
+
+// Flush writes any buffered data to the underlying writer and returns the error of the csv.Writer,
+// if any.
+func (w *Writer) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
@@ -0,0 +1,194 @@
+package csvrecord
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mbe81/jsontype"
+)
+
+type person struct {
+	Name     jsontype.NullString    `csv:"name"`
+	Age      jsontype.NullUint8     `csv:"age"`
+	Born     jsontype.NullTime      `csv:"born"`
+	Score    jsontype.Null[float64] `csv:"score"`
+	City     jsontype.NullString
+	Internal jsontype.NullString `csv:"-"`
+	Ignored  string              `csv:"ignored"`
+}
+
+// Test the Read method of Reader
+func TestReader_Read(t *testing.T) {
+	born := time.Date(2000, 01, 01, 00, 00, 00, 00, time.UTC)
+	tests := []struct {
+		name        string
+		input       string
+		opts        []Option
+		expected    []person
+		expectedErr string
+	}{
+		{
+			name:  "All states",
+			input: "name,age,born,score,City,Internal,ignored\nJohn,42,2000-01-01T00:00:00Z,1.5,Boston,x,y\n,\\N,\\N,,\\N,x,y\n",
+			expected: []person{
+				{
+					Name:  jsontype.NullString{String: "John", Valid: true, Present: true},
+					Age:   jsontype.NullUint8{Uint8: 42, Valid: true, Present: true},
+					Born:  jsontype.NullTime{Time: born, Valid: true, Present: true},
+					Score: jsontype.Value(1.5),
+					City:  jsontype.NullString{String: "Boston", Valid: true, Present: true},
+				},
+				{
+					Name: jsontype.NullString{Valid: true, Present: true},
+					Age:  jsontype.NullUint8{Present: true},
+					Born: jsontype.NullTime{Present: true},
+					City: jsontype.NullString{Present: true},
+				},
+			},
+			expectedErr: `csvrecord: cannot parse "" in column "score" on line 3: strconv.ParseFloat: parsing "": invalid syntax`,
+		},
+		{
+			name:  "Missing columns",
+			input: "age,name\n7,Jane\n",
+			expected: []person{
+				{
+					Name: jsontype.NullString{String: "Jane", Valid: true, Present: true},
+					Age:  jsontype.NullUint8{Uint8: 7, Valid: true, Present: true},
+				},
+			},
+		},
+		{
+			name:  "Custom null tokens",
+			input: "name,age\nNULL,\nnull,\\N\n",
+			opts:  []Option{NullTokens("NULL", "", "null")},
+			expected: []person{
+				{Name: jsontype.NullString{Present: true}, Age: jsontype.NullUint8{Present: true}},
+				{Name: jsontype.NullString{Present: true}, Age: jsontype.NullUint8{}},
+			},
+			expectedErr: `csvrecord: cannot parse "\\N" in column "age" on line 3: strconv.ParseUint: parsing "\\N": invalid syntax`,
+		},
+		{
+			name:  "Multiple errors",
+			input: "name,age,born\nJohn,300,yesterday\n",
+			expected: []person{
+				{Name: jsontype.NullString{String: "John", Valid: true, Present: true}},
+			},
+			expectedErr: `csvrecord: cannot parse "300" in column "age" on line 2: strconv.ParseUint: parsing "300": value out of range; ` +
+				`csvrecord: cannot parse "yesterday" in column "born" on line 2: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+		{
+			name:     "Empty file",
+			input:    "",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReader(csv.NewReader(strings.NewReader(tt.input)), tt.opts...)
+			var result []person
+			var errs []string
+			for {
+				var p person
+				err := r.Read(&p)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					errs = append(errs, err.Error())
+				}
+				result = append(result, p)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Read() = %+v, expected %+v", result, tt.expected)
+			}
+			if strings.Join(errs, "; ") != tt.expectedErr {
+				t.Errorf("Read() errors = %s, expected %s", strings.Join(errs, "; "), tt.expectedErr)
+			}
+		})
+	}
+}
+
+// Test the errors of Reader that are not CellErrors
+func TestReader_Errors(t *testing.T) {
+	r := NewReader(csv.NewReader(strings.NewReader("name,age\nJohn\n")))
+	var pe *csv.ParseError
+	if err := r.Read(&person{}); !errors.As(err, &pe) {
+		t.Errorf("Read() error = %v, expected a *csv.ParseError", err)
+	}
+	if err := r.Read(person{}); err == nil {
+		t.Errorf("Read() error = nil, expected an error for a non-pointer")
+	}
+	if header, err := r.Header(); err != nil || !reflect.DeepEqual(header, []string{"name", "age"}) {
+		t.Errorf("Header() = %v, %v, expected [name age]", header, err)
+	}
+}
+
+// Test the Write method of Writer
+func TestWriter_Write(t *testing.T) {
+	born := time.Date(2000, 01, 01, 12, 30, 00, 00, time.UTC)
+	tests := []struct {
+		name     string
+		input    []any
+		opts     []Option
+		expected string
+	}{
+		{
+			name: "All states",
+			input: []any{
+				person{
+					Name:  jsontype.NullString{String: "John, Jr.", Valid: true, Present: true},
+					Age:   jsontype.NullUint8{Uint8: 42, Valid: true, Present: true},
+					Born:  jsontype.NullTime{Time: born, Valid: true, Present: true},
+					Score: jsontype.Value(1.5),
+				},
+				&person{Name: jsontype.NullString{Valid: true, Present: true}, Age: jsontype.NullUint8{Present: true}},
+			},
+			expected: "name,age,born,score,City\n\"John, Jr.\",42,2000-01-01T12:30:00Z,1.5,\n,\\N,,,\n",
+		},
+		{
+			name:     "Custom null token",
+			input:    []any{person{Name: jsontype.NullString{Present: true}}},
+			opts:     []Option{NullTokens("NULL")},
+			expected: "name,age,born,score,City\nNULL,,,,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(csv.NewWriter(&buf), tt.opts...)
+			for _, v := range tt.input {
+				if err := w.Write(v); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Write() = %q, expected %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+// Test that Writer rejects values of another type than the first record
+func TestWriter_Write_Errors(t *testing.T) {
+	w := NewWriter(csv.NewWriter(io.Discard))
+	if err := w.Write("hello"); err == nil {
+		t.Errorf("Write() error = nil, expected an error for a string")
+	}
+	if err := w.Write(person{}); err != nil {
+		t.Errorf("Write() error = %v", err)
+	}
+	if err := w.Write(struct{ Name jsontype.NullString }{}); err == nil {
+		t.Errorf("Write() error = nil, expected an error for another type")
+	}
+}
@@ -0,0 +1,179 @@
+package jsontype
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// Diff compares old and new, two values of the same struct type or pointers to them, and returns a
+// patch of type P describing the changes, the inverse of Apply.
+//
+// P must be a struct type containing the Null types of this package. Its fields are matched to the
+// fields of old and new as in Apply, by Go field name or by the name in their json tag. A Null field
+// of the patch is set to present only if the matching values differ: to the new value if it is not
+// nil, and to null if it is. All other Null fields are absent, so that Marshal writes the smallest
+// JSON Merge Patch (RFC 7386) transforming old into new. Struct fields of P that are not Null types
+// are compared recursively; other fields of P are ignored.
+//
+// A pointer to a struct that changes from nil is diffed as a whole, with all Null fields of the
+// patch struct present. A patch struct cannot be null, so a pointer to a struct that changes to nil
+// must be matched by a Null field of P, like Null[*T]; otherwise Diff returns an error.
+//
+// Values are compared by their Equal method if they have one, like time.Time, and by
+// reflect.DeepEqual otherwise. The new value must be assignable to the value of the patch field,
+// after dereferencing it if it is a pointer.
+func Diff[P any](old, new any) (P, error) {
+	var patch P
+	pv := reflect.ValueOf(&patch).Elem()
+	if pv.Kind() != reflect.Struct {
+		return patch, fmt.Errorf("jsontype: Diff patch must be a struct, got %s", pv.Type())
+	}
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(new)
+	if ov.Kind() == reflect.Pointer && !ov.IsNil() {
+		ov = ov.Elem()
+	}
+	if nv.Kind() == reflect.Pointer && !nv.IsNil() {
+		nv = nv.Elem()
+	}
+	if ov.Kind() != reflect.Struct || !nv.IsValid() || ov.Type() != nv.Type() {
+		return patch, fmt.Errorf("jsontype: Diff requires two structs of the same type, got %T and %T", old, new)
+	}
+	if err := diffStruct(pv, ov, nv, "", false); err != nil {
+		var zero P
+		return zero, err
+	}
+	return patch, nil
+}
+
+// diffStruct sets the fields of patch to the changes from old to new. If whole is set, all Null
+// fields are set, changed or not.
+func diffStruct(patch, old, new reflect.Value, path string, whole bool) error {
+	fields := typeinfo.Fields(old.Type(), "json")
+	for _, pf := range typeinfo.Fields(patch.Type(), "json") {
+		pv := reflect.New(pf.StructField.Type).Elem()
+		st := pv.Type()
+		if st.Kind() == reflect.Pointer {
+			st = st.Elem()
+		}
+		if st.Kind() != reflect.Struct {
+			continue
+		}
+		fpath := appendPointer(path, pf.Name)
+		f, found := matchField(fields, pf)
+		if !found {
+			return fmt.Errorf("jsontype: cannot diff %s: no matching field in %s", fpath, old.Type())
+		}
+		of, _ := typeinfo.FieldByIndex(old, f.Index)
+		nf, _ := typeinfo.FieldByIndex(new, f.Index)
+
+		if value, valid, present, ok := typeinfo.NullParts(pv); ok {
+			if !whole && equal(of, nf) {
+				continue
+			}
+			if nf, ok := newValue(nf); ok {
+				if err := assign(value, nf); err != nil {
+					return fmt.Errorf("jsontype: cannot diff %s: %w", fpath, err)
+				}
+				valid.SetBool(true)
+			}
+			present.SetBool(true)
+			if err := setField(patch, pf, pv, fpath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		ft := of.Type()
+		switch {
+		case isNil(of) && isNil(nf):
+			continue
+		case isNil(nf):
+			return fmt.Errorf("jsontype: cannot diff %s: a patch struct cannot be null, use a Null field", fpath)
+		}
+		fwhole := whole || isNil(of)
+		if of, nf = structValue(of), structValue(nf); !of.IsValid() {
+			return fmt.Errorf("jsontype: cannot diff %s: %s is not a struct", fpath, ft)
+		}
+		sv := reflect.New(st).Elem()
+		if err := diffStruct(sv, of, nf, fpath, fwhole); err != nil {
+			return err
+		}
+		if !hasPresent(sv) {
+			continue
+		}
+		if pv.Kind() == reflect.Pointer {
+			pv.Set(sv.Addr())
+		} else {
+			pv.Set(sv)
+		}
+		if err := setField(patch, pf, pv, fpath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setField sets the field f of the patch to v, allocating the embedded structs it is in.
+func setField(patch reflect.Value, f typeinfo.Field, v reflect.Value, path string) error {
+	pv, ok := typeinfo.SettableFieldByIndex(patch, f.Index)
+	if !ok {
+		return fmt.Errorf("jsontype: cannot diff %s: embedded pointer to unexported struct in %s", path, patch.Type())
+	}
+	pv.Set(v)
+	return nil
+}
+
+// newValue returns the new value v of a changed field for the patch, dereferencing pointers and
+// unwrapping Null types. It returns false if the value is nil or null.
+func newValue(v reflect.Value) (reflect.Value, bool) {
+	if value, valid, _, ok := typeinfo.NullParts(v); ok {
+		return value, valid.Bool()
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v, false
+		}
+		return v.Elem(), true
+	case reflect.Interface, reflect.Slice, reflect.Map:
+		return v, !v.IsNil()
+	}
+	return v, true
+}
+
+// structValue returns v, the struct v points to, or the zero struct if v is a nil pointer to a
+// struct. It returns the zero Value if v is not a struct.
+func structValue(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Pointer {
+		if v.Type().Elem().Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return v
+}
+
+// isNil reports whether v is a nil pointer.
+func isNil(v reflect.Value) bool {
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// equal reports whether a and b, values of the same type, are equal, using their Equal method if
+// they have one.
+func equal(a, b reflect.Value) bool {
+	if m := a.MethodByName("Equal"); m.IsValid() {
+		mt := m.Type()
+		if mt.NumIn() == 1 && mt.In(0) == b.Type() && mt.NumOut() == 1 && mt.Out(0).Kind() == reflect.Bool {
+			return m.Call([]reflect.Value{b})[0].Bool()
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
@@ -0,0 +1,249 @@
+package jsontype
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type diffAddress struct {
+	Street string
+	City   string
+}
+
+type diffPerson struct {
+	Name     string
+	Nickname *string
+	Age      int
+	Tags     []string
+	Birthday time.Time
+	Email    NullString
+	Address  diffAddress
+	Home     *diffAddress `json:"home"`
+	Internal int
+}
+
+type diffPatchAddress struct {
+	Street NullString `json:"street"`
+	City   NullString `json:"city"`
+}
+
+type diffPatch struct {
+	Name     NullString        `json:"name"`
+	Nickname Null[string]      `json:"nickname"`
+	Age      NullInt           `json:"age"`
+	Tags     Null[[]string]    `json:"tags"`
+	Birthday NullTime          `json:"birthday"`
+	Email    Null[*string]     `json:"email"`
+	Address  diffPatchAddress  `json:"address"`
+	Home     *diffPatchAddress `json:"home,omitempty"`
+	Other    string            `json:"other,omitempty"`
+}
+
+func newDiffPerson() diffPerson {
+	nick := "Jo"
+	return diffPerson{
+		Name:     "John",
+		Nickname: &nick,
+		Age:      42,
+		Tags:     []string{"a"},
+		Birthday: time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC),
+		Email:    NullString{String: "john@example.com", Valid: true, Present: true},
+		Address:  diffAddress{Street: "Main Street", City: "Boston"},
+	}
+}
+
+// Test the Diff function
+func TestDiff(t *testing.T) {
+	email := "jane@example.com"
+	tests := []struct {
+		name     string
+		change   func(p *diffPerson)
+		expected diffPatch
+		json     string
+	}{
+		{
+			name:   "Unchanged",
+			change: func(p *diffPerson) { p.Birthday = p.Birthday.In(time.FixedZone("CET", 3600)) },
+			json:   `{"address":{}}`,
+		},
+		{
+			name: "Changed values",
+			change: func(p *diffPerson) {
+				p.Name = "Jane"
+				p.Age = 43
+				p.Tags = []string{"a", "b"}
+				p.Email.String = email
+				p.Address.City = "Denver"
+			},
+			expected: diffPatch{
+				Name:    NullString{String: "Jane", Valid: true, Present: true},
+				Age:     NullInt{Int: 43, Valid: true, Present: true},
+				Tags:    Null[[]string]{Value: []string{"a", "b"}, Valid: true, Present: true},
+				Email:   Null[*string]{Value: &email, Valid: true, Present: true},
+				Address: diffPatchAddress{City: NullString{String: "Denver", Valid: true, Present: true}},
+			},
+			json: `{"name":"Jane","age":43,"tags":["a","b"],"email":"jane@example.com","address":{"city":"Denver"}}`,
+		},
+		{
+			name: "Changed to nil",
+			change: func(p *diffPerson) {
+				p.Nickname = nil
+				p.Tags = nil
+				p.Email = NullString{Present: true}
+			},
+			expected: diffPatch{
+				Nickname: Null[string]{Present: true},
+				Tags:     Null[[]string]{Present: true},
+				Email:    Null[*string]{Present: true},
+			},
+			json: `{"nickname":null,"tags":null,"email":null,"address":{}}`,
+		},
+		{
+			name:   "Pointer to struct",
+			change: func(p *diffPerson) { p.Home = &diffAddress{Street: "Elm Street"} },
+			expected: diffPatch{
+				Home: &diffPatchAddress{
+					Street: NullString{String: "Elm Street", Valid: true, Present: true},
+					City:   NullString{Valid: true, Present: true},
+				},
+			},
+			json: `{"address":{},"home":{"street":"Elm Street","city":""}}`,
+		},
+		{
+			name:   "Pointer to zero struct",
+			change: func(p *diffPerson) { p.Home = &diffAddress{} },
+			expected: diffPatch{
+				Home: &diffPatchAddress{Street: NullString{Valid: true, Present: true}, City: NullString{Valid: true, Present: true}},
+			},
+			json: `{"address":{},"home":{"street":"","city":""}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, new := newDiffPerson(), newDiffPerson()
+			tt.change(&new)
+			result, err := Diff[diffPatch](old, &new)
+			if err != nil {
+				t.Errorf("Diff() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Diff() = %+v, expected %+v", result, tt.expected)
+			}
+			b, err := Marshal(result)
+			if err != nil {
+				t.Errorf("Marshal() error = %v", err)
+				return
+			}
+			if string(b) != tt.json {
+				t.Errorf("Marshal(Diff()) = %s, expected %s", b, tt.json)
+			}
+		})
+	}
+}
+
+// Test that Apply of the result of Diff transforms old into new
+func TestDiff_Apply(t *testing.T) {
+	old, new := newDiffPerson(), newDiffPerson()
+	new.Name = "Jane"
+	new.Nickname = nil
+	new.Address.Street = ""
+	patch, err := Diff[diffPatch](old, new)
+	if err != nil {
+		t.Errorf("Diff() error = %v", err)
+		return
+	}
+	if err := Apply(&old, patch); err != nil {
+		t.Errorf("Apply() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(old, new) {
+		t.Errorf("Apply(Diff()) = %+v, expected %+v", old, new)
+	}
+}
+
+// Test that Diff sets a pointer to a struct from and to nil, with both a patch struct and a Null field
+func TestDiff_NilPointer(t *testing.T) {
+	type homePatch struct {
+		Home Null[*diffAddress] `json:"home"`
+	}
+	tests := []struct {
+		name     string
+		old, new diffPerson
+		diff     func(old, new diffPerson) (any, error)
+		json     string
+	}{
+		{
+			name: "From nil to patch struct",
+			new:  diffPerson{Home: &diffAddress{}},
+			diff: func(old, new diffPerson) (any, error) { return Diff[diffPatch](old, new) },
+			json: `{"address":{},"home":{"street":"","city":""}}`,
+		},
+		{
+			name: "From nil to Null field",
+			new:  diffPerson{Home: &diffAddress{}},
+			diff: func(old, new diffPerson) (any, error) { return Diff[homePatch](old, new) },
+			json: `{"home":{"Street":"","City":""}}`,
+		},
+		{
+			name: "To nil with Null field",
+			old:  diffPerson{Home: &diffAddress{City: "X"}},
+			diff: func(old, new diffPerson) (any, error) { return Diff[homePatch](old, new) },
+			json: `{"home":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := tt.diff(tt.old, tt.new)
+			if err != nil {
+				t.Errorf("Diff() error = %v", err)
+				return
+			}
+			if b, err := Marshal(patch); err != nil || string(b) != tt.json {
+				t.Errorf("Marshal(Diff()) = %s, %v, expected %s", b, err, tt.json)
+			}
+			if err := Apply(&tt.old, patch); err != nil {
+				t.Errorf("Apply() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.old, tt.new) {
+				t.Errorf("Apply(Diff()) = %+v, expected %+v", tt.old, tt.new)
+			}
+		})
+	}
+}
+
+// Test the errors of the Diff function
+func TestDiff_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		diff func() error
+	}{
+		{name: "Different types", diff: func() error { _, err := Diff[diffPatch](diffPerson{}, diffAddress{}); return err }},
+		{name: "Nil", diff: func() error { _, err := Diff[diffPatch](nil, nil); return err }},
+		{name: "Patch not a struct", diff: func() error { _, err := Diff[int](diffPerson{}, diffPerson{}); return err }},
+		{name: "No matching field", diff: func() error {
+			_, err := Diff[struct{ Missing NullInt }](diffPerson{}, diffPerson{})
+			return err
+		}},
+		{name: "Pointer to struct changed to nil", diff: func() error {
+			_, err := Diff[diffPatch](diffPerson{Home: &diffAddress{City: "X"}}, diffPerson{})
+			return err
+		}},
+		{name: "Not assignable", diff: func() error {
+			_, err := Diff[struct{ Name NullInt }](diffPerson{}, diffPerson{Name: "x"})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.diff(); err == nil {
+				t.Errorf("Diff() error = nil, expected an error")
+			}
+		})
+	}
+}
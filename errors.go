@@ -0,0 +1,151 @@
+package jsontype
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Errors is a list of errors, such as the violations reported by Validate.
+type Errors []error
+
+// Error implements the error interface.
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors in the list, so that errors.Is and errors.As inspect each of them.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// ValidationError describes a field that violates a rule of its jsontype struct tag.
+type ValidationError struct {
+	Path string // Path is the JSON Pointer (RFC 6901) to the field
+	Rule string // Rule is the violated rule: "required", "notnull" or "forbidden"
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	switch e.Rule {
+	case ruleRequired:
+		return fmt.Sprintf("jsontype: %s is required", e.Path)
+	case ruleNotNull:
+		return fmt.Sprintf("jsontype: %s must not be null", e.Path)
+	case ruleForbidden:
+		return fmt.Sprintf("jsontype: %s is not allowed", e.Path)
+	}
+	return fmt.Sprintf("jsontype: %s violates rule %q", e.Path, e.Rule)
+}
+
+// DecodeError describes a JSON value that cannot be decoded. It is returned by the UnmarshalJSON
+// methods of the Null types, without a path, and by Unmarshal, with the path of the value.
+type DecodeError struct {
+	Path     string // Path is the JSON Pointer (RFC 6901) to the value, or empty if it is unknown
+	Expected string // Expected is the kind of JSON value expected, such as "integer" or "string"
+	Value    []byte // Value is the raw JSON value that cannot be decoded, or empty if it is unknown
+	Nullable bool   // Nullable is true if null is allowed for the value
+	Err      error  // Err is the underlying error
+}
+
+// maxErrorValue is the maximum length of the value included in the message of a DecodeError.
+const maxErrorValue = 40
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	var b strings.Builder
+	b.WriteString("jsontype: cannot unmarshal ")
+	if len(e.Value) == 0 {
+		b.WriteString("value")
+	} else if len(e.Value) > maxErrorValue {
+		b.Write(e.Value[:maxErrorValue])
+		b.WriteString("...")
+	} else {
+		b.Write(e.Value)
+	}
+	if e.Path != "" {
+		b.WriteString(" at ")
+		b.WriteString(e.Path)
+	}
+	b.WriteString(": expected ")
+	b.WriteString(e.Expected)
+	if e.Nullable {
+		b.WriteString(" or null")
+	}
+	if e.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.Err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError returns a *DecodeError for data that cannot be decoded into a Null type because
+// of err.
+func newDecodeError(data []byte, expected string, err error) *DecodeError {
+	return &DecodeError{Expected: expected, Value: append([]byte(nil), data...), Nullable: true, Err: err}
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// jsonKind returns the kind of JSON value that json.Unmarshal decodes into values of type t.
+func jsonKind(t reflect.Type) string {
+	switch {
+	case t == timeType:
+		return "string"
+	case reflect.PointerTo(t).Implements(jsonUnmarshalerType):
+		return "value"
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return "array"
+	case reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Pointer:
+		return jsonKind(t.Elem())
+	}
+	return "value"
+}
+
+// UnknownFieldError describes an object member that does not match a struct field, reported by
+// Unmarshal with the DisallowUnknownFields option, or a field path that does not match a struct
+// field, reported by MarshalFields.
+type UnknownFieldError struct {
+	Path string // Path is the JSON Pointer (RFC 6901) to the member or field
+}
+
+// Error implements the error interface.
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("jsontype: unknown field %s", e.Path)
+}
@@ -0,0 +1,294 @@
+package jsontype
+
+import (
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// The parsers in this file decode the common forms of JSON booleans, numbers, strings and times
+// without reflection or allocations (except for the bytes of strings). They return false for any
+// input they do not handle, including all invalid input, in which case the caller decodes the
+// value with json.Unmarshal. For the input they accept, they return exactly what json.Unmarshal
+// returns.
+
+// parseBool parses the JSON literals true and false.
+func parseBool(data []byte) (bool, bool) {
+	switch string(data) {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	}
+	return false, false
+}
+
+// maxFastDigits is the maximum number of digits parsed by parseInt, so that the result cannot
+// overflow an int64.
+const maxFastDigits = 18
+
+// parseInt parses a JSON number without fraction or exponent of up to maxFastDigits digits that
+// fits in an int.
+func parseInt(data []byte) (int, bool) {
+	i, neg := 0, false
+	if len(data) > 0 && data[0] == '-' {
+		i, neg = 1, true
+	}
+	digits := data[i:]
+	if len(digits) == 0 || len(digits) > maxFastDigits || (digits[0] == '0' && len(digits) > 1) {
+		return 0, false
+	}
+	var n int64
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int64(c-'0')
+	}
+	if neg {
+		n = -n
+	}
+	if int64(int(n)) != n {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// parseFloat parses a JSON number that is in range for a float64.
+func parseFloat(data []byte) (float64, bool) {
+	if !isJSONNumber(data) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// isJSONNumber reports whether data is a number as defined by the JSON grammar (RFC 8259).
+func isJSONNumber(data []byte) bool {
+	i := 0
+	if i < len(data) && data[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(data) && data[i] == '0':
+		i++
+	case i < len(data) && data[i] >= '1' && data[i] <= '9':
+		i = skipDigits(data, i)
+	default:
+		return false
+	}
+	if i < len(data) && data[i] == '.' {
+		j := skipDigits(data, i+1)
+		if j == i+1 {
+			return false
+		}
+		i = j
+	}
+	if i < len(data) && (data[i] == 'e' || data[i] == 'E') {
+		i++
+		if i < len(data) && (data[i] == '+' || data[i] == '-') {
+			i++
+		}
+		j := skipDigits(data, i)
+		if j == i {
+			return false
+		}
+		i = j
+	}
+	return i == len(data)
+}
+
+func skipDigits(data []byte, i int) int {
+	for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// parseString parses a JSON string. Like json.Unmarshal, it replaces invalid UTF-8 and unpaired
+// surrogate escapes with the Unicode replacement character.
+func parseString(data []byte) (string, bool) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return "", false
+	}
+	s := data[1 : len(data)-1]
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		if c == '\\' || c == '"' || c < ' ' {
+			break
+		}
+		if c < utf8.RuneSelf {
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			break
+		}
+		i += size
+	}
+	if i == len(s) {
+		return string(s), true
+	}
+	return unquote(s, i)
+}
+
+// unquote decodes the contents s of a JSON string whose first i bytes need no decoding.
+func unquote(s []byte, i int) (string, bool) {
+	b := make([]byte, i, len(s)+utf8.UTFMax)
+	copy(b, s[:i])
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(s) {
+				return "", false
+			}
+			switch s[i+1] {
+			case '"', '\\', '/':
+				b = append(b, s[i+1])
+			case 'b':
+				b = append(b, '\b')
+			case 'f':
+				b = append(b, '\f')
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case 'u':
+				r, ok := parseHex4(s[i+2:])
+				if !ok {
+					return "", false
+				}
+				i += 6
+				if utf16.IsSurrogate(r) {
+					if r2, ok := parseEscapedRune(s[i:]); ok {
+						if dec := utf16.DecodeRune(r, r2); dec != unicode.ReplacementChar {
+							b = utf8.AppendRune(b, dec)
+							i += 6
+							continue
+						}
+					}
+					r = unicode.ReplacementChar
+				}
+				b = utf8.AppendRune(b, r)
+				continue
+			default:
+				return "", false
+			}
+			i += 2
+		case c == '"' || c < ' ':
+			return "", false
+		case c < utf8.RuneSelf:
+			b = append(b, c)
+			i++
+		default:
+			r, size := utf8.DecodeRune(s[i:])
+			i += size
+			b = utf8.AppendRune(b, r)
+		}
+	}
+	return string(b), true
+}
+
+// parseEscapedRune parses the escape \uXXXX at the start of s.
+func parseEscapedRune(s []byte) (rune, bool) {
+	if len(s) < 2 || s[0] != '\\' || s[1] != 'u' {
+		return 0, false
+	}
+	return parseHex4(s[2:])
+}
+
+// parseHex4 parses the four hexadecimal digits at the start of s.
+func parseHex4(s []byte) (rune, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	var r rune
+	for _, c := range s[:4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			return 0, false
+		}
+		r = r*16 + rune(c)
+	}
+	return r, true
+}
+
+// parseTime parses a JSON string holding an RFC 3339 time in UTC, such as
+// "2006-01-02T15:04:05.999999999Z".
+func parseTime(data []byte) (time.Time, bool) {
+	// "2006-01-02T15:04:05Z" is the shortest form, with an optional fraction before the Z.
+	if len(data) < 22 || data[0] != '"' || data[len(data)-1] != '"' || data[len(data)-2] != 'Z' {
+		return time.Time{}, false
+	}
+	s := data[1 : len(data)-2]
+	if s[4] != '-' || s[7] != '-' || s[10] != 'T' || s[13] != ':' || s[16] != ':' {
+		return time.Time{}, false
+	}
+	year, ok1 := parseDigits(s[0:4])
+	month, ok2 := parseDigits(s[5:7])
+	day, ok3 := parseDigits(s[8:10])
+	hour, ok4 := parseDigits(s[11:13])
+	minute, ok5 := parseDigits(s[14:16])
+	sec, ok6 := parseDigits(s[17:19])
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || !ok6 ||
+		month < 1 || month > 12 || day < 1 || day > daysIn(month, year) || hour > 23 || minute > 59 || sec > 59 {
+		return time.Time{}, false
+	}
+	var nsec int
+	if frac := s[19:]; len(frac) > 0 {
+		if len(frac) < 2 || len(frac) > 10 || frac[0] != '.' {
+			return time.Time{}, false
+		}
+		n, ok := parseDigits(frac[1:])
+		if !ok {
+			return time.Time{}, false
+		}
+		for i := len(frac); i < 10; i++ {
+			n *= 10
+		}
+		nsec = n
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, sec, nsec, time.UTC), true
+}
+
+// parseDigits parses the decimal digits s.
+func parseDigits(s []byte) (int, bool) {
+	n := 0
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+// daysIn returns the number of days in month of year.
+func daysIn(month, year int) int {
+	switch month {
+	case 2:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
@@ -0,0 +1,174 @@
+package jsontype
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+// The fast path parsers with a common signature for the tests.
+var (
+	boolParser   = func(data []byte) (any, bool) { v, ok := parseBool(data); return v, ok }
+	intParser    = func(data []byte) (any, bool) { v, ok := parseInt(data); return v, ok }
+	floatParser  = func(data []byte) (any, bool) { v, ok := parseFloat(data); return v, ok }
+	stringParser = func(data []byte) (any, bool) { v, ok := parseString(data); return v, ok }
+	timeParser   = func(data []byte) (any, bool) { v, ok := parseTime(data); return v, ok }
+)
+
+// Test the fast path parsers
+func TestFastPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		parser   func([]byte) (any, bool)
+		input    string
+		expected any
+		ok       bool // ok is false if the parser must fall back to json.Unmarshal
+	}{
+		{name: "Bool true", parser: boolParser, input: `true`, expected: true, ok: true},
+		{name: "Bool false", parser: boolParser, input: `false`, expected: false, ok: true},
+		{name: "Bool with space", parser: boolParser, input: ` true`},
+		{name: "Int", parser: intParser, input: `123`, expected: 123, ok: true},
+		{name: "Int zero", parser: intParser, input: `0`, expected: 0, ok: true},
+		{name: "Int negative", parser: intParser, input: `-42`, expected: -42, ok: true},
+		{name: "Int 18 digits", parser: intParser, input: `999999999999999999`, expected: 999999999999999999, ok: true},
+		{name: "Int 19 digits", parser: intParser, input: `1000000000000000000`},
+		{name: "Int leading zero", parser: intParser, input: `012`},
+		{name: "Int minus only", parser: intParser, input: `-`},
+		{name: "Int fraction", parser: intParser, input: `1.0`},
+		{name: "Float", parser: floatParser, input: `1.5`, expected: 1.5, ok: true},
+		{name: "Float exponent", parser: floatParser, input: `-1.25E+3`, expected: -1250.0, ok: true},
+		{name: "Float out of range", parser: floatParser, input: `1e400`},
+		{name: "Float leading dot", parser: floatParser, input: `.5`},
+		{name: "Float trailing dot", parser: floatParser, input: `5.`},
+		{name: "Float empty exponent", parser: floatParser, input: `5e`},
+		{name: "Float hex", parser: floatParser, input: `0x10`},
+		{name: "String", parser: stringParser, input: `"John"`, expected: "John", ok: true},
+		{name: "String empty", parser: stringParser, input: `""`, expected: "", ok: true},
+		{name: "String UTF-8", parser: stringParser, input: `"Zoë 😀"`, expected: "Zoë 😀", ok: true},
+		{name: "String escapes", parser: stringParser, input: `"a\"b\\c\/d\b\f\n\r\t"`, expected: "a\"b\\c/d\b\f\n\r\t", ok: true},
+		{name: "String unicode escape", parser: stringParser, input: `"\u00e9\u20AC"`, expected: "é€", ok: true},
+		{name: "String surrogate pair", parser: stringParser, input: `"\ud83d\ude00"`, expected: "😀", ok: true},
+		{name: "String unpaired surrogate", parser: stringParser, input: `"\ud83dx"`, expected: "�x", ok: true},
+		{name: "String reversed surrogates", parser: stringParser, input: `"\ude00\ud83d"`, expected: "��", ok: true},
+		{name: "String invalid UTF-8", parser: stringParser, input: "\"a\xffb\"", expected: "a�b", ok: true},
+		{name: "String control character", parser: stringParser, input: "\"a\nb\""},
+		{name: "String unknown escape", parser: stringParser, input: `"\x"`},
+		{name: "String short unicode escape", parser: stringParser, input: `"\u12"`},
+		{name: "String unescaped quote", parser: stringParser, input: `"a"b"`},
+		{name: "String trailing backslash", parser: stringParser, input: `"a\"`},
+		{name: "Time", parser: timeParser, input: `"2024-02-29T23:59:59Z"`, expected: time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC), ok: true},
+		{name: "Time fraction", parser: timeParser, input: `"2024-01-02T03:04:05.5Z"`, expected: time.Date(2024, 1, 2, 3, 4, 5, 500000000, time.UTC), ok: true},
+		{name: "Time nanoseconds", parser: timeParser, input: `"2024-01-02T03:04:05.123456789Z"`, expected: time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC), ok: true},
+		{name: "Time offset", parser: timeParser, input: `"2024-01-02T03:04:05+01:00"`},
+		{name: "Time invalid day", parser: timeParser, input: `"2023-02-29T00:00:00Z"`},
+		{name: "Time invalid hour", parser: timeParser, input: `"2023-01-01T24:00:00Z"`},
+		{name: "Time empty fraction", parser: timeParser, input: `"2023-01-01T00:00:00.Z"`},
+		{name: "Time long fraction", parser: timeParser, input: `"2023-01-01T00:00:00.1234567891Z"`},
+		{name: "Time lowercase", parser: timeParser, input: `"2023-01-01t00:00:00z"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.parser([]byte(tt.input))
+			if ok != tt.ok || (ok && got != tt.expected) {
+				t.Errorf("parse(%s) = %v, %t, expected %v, %t", tt.input, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+// Test that the fast path parsers agree with json.Unmarshal
+func FuzzFastPath(f *testing.F) {
+	for _, s := range []string{
+		`true`, `false`, `null`, `0`, `-0`, `123`, `-9223372036854775808`, `1.5e3`, `-0.0`, `1e400`,
+		`"John"`, `"a\"b\\c\/d\b\f\n\r\t"`, `"\ud83d\ude00"`, `"\ud83d"`, "\"a\xffb\"", `"\u0000"`,
+		`"2024-02-29T23:59:59.123456789Z"`, `"0000-01-01T00:00:00Z"`, `"2023-02-29T00:00:00Z"`,
+	} {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if v, ok := parseBool(data); ok {
+			var expected bool
+			if err := json.Unmarshal(data, &expected); err != nil || v != expected {
+				t.Errorf("parseBool(%q) = %v, json.Unmarshal = %v, %v", data, v, expected, err)
+			}
+		}
+		if v, ok := parseInt(data); ok {
+			var expected int
+			if err := json.Unmarshal(data, &expected); err != nil || v != expected {
+				t.Errorf("parseInt(%q) = %v, json.Unmarshal = %v, %v", data, v, expected, err)
+			}
+		}
+		if v, ok := parseFloat(data); ok {
+			var expected float64
+			if err := json.Unmarshal(data, &expected); err != nil || math.Float64bits(v) != math.Float64bits(expected) {
+				t.Errorf("parseFloat(%q) = %v, json.Unmarshal = %v, %v", data, v, expected, err)
+			}
+		}
+		if v, ok := parseString(data); ok {
+			var expected string
+			if err := json.Unmarshal(data, &expected); err != nil || v != expected {
+				t.Errorf("parseString(%q) = %q, json.Unmarshal = %q, %v", data, v, expected, err)
+			}
+		}
+		if v, ok := parseTime(data); ok {
+			var expected time.Time
+			if err := json.Unmarshal(data, &expected); err != nil || v != expected {
+				t.Errorf("parseTime(%q) = %v, json.Unmarshal = %v, %v", data, v, expected, err)
+			}
+		}
+	})
+}
+
+// Test that unmarshaling numbers and booleans does not allocate
+func TestUnmarshalJSON_Allocs(t *testing.T) {
+	var nb NullBool
+	var ni NullInt
+	var nf NullFloat64
+	boolData, intData, floatData := []byte(`true`), []byte(`-12345`), []byte(`1.25e3`)
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{name: "NullBool", f: func() { nb.UnmarshalJSON(boolData) }},
+		{name: "NullInt", f: func() { ni.UnmarshalJSON(intData) }},
+		{name: "NullFloat64", f: func() { nf.UnmarshalJSON(floatData) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(100, tt.f); allocs != 0 {
+				t.Errorf("UnmarshalJSON() allocs = %v, expected 0", allocs)
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		dst   json.Unmarshaler
+		input string
+	}{
+		{name: "NullBool", dst: &NullBool{}, input: `true`},
+		{name: "NullInt", dst: &NullInt{}, input: `-12345`},
+		{name: "NullFloat64", dst: &NullFloat64{}, input: `1.25e3`},
+		{name: "NullString", dst: &NullString{}, input: `"John Doe"`},
+		{name: "NullString escaped", dst: &NullString{}, input: `"John \"Johnny\" Doe\n"`},
+		{name: "NullTime", dst: &NullTime{}, input: `"2024-01-02T03:04:05.123456789Z"`},
+		{name: "NullTime offset", dst: &NullTime{}, input: `"2024-01-02T03:04:05+01:00"`},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			data := []byte(bm.input)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := bm.dst.UnmarshalJSON(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
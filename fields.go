@@ -0,0 +1,177 @@
+package jsontype
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// MarshalFields returns the JSON encoding of v, like Marshal, but with only the struct fields
+// selected by fields, such as the sparse fieldset of a GET request with ?fields=name,address.city.
+//
+// Each field is a path of JSON field names separated by dots. A path selects the field and, for
+// struct fields, all of its fields, while a longer path such as address.city selects only the city
+// field of the address. Paths are resolved through pointers, Null types and the elements of slices
+// and arrays, so that the same fields can be selected from a slice of structs. The selected fields
+// are written as by Marshal: a null field is written as null, while absent fields and fields
+// omitted by their tag options are left out like the fields that are not selected. Values that
+// are not structs, such as maps or the elements of a slice of strings, are written as by Marshal.
+//
+// MarshalFields returns an *UnknownFieldError, with the JSON Pointer of the path, if a path does not
+// name a field of the type of v, even if the value of a field on the path is null. Empty paths are
+// ignored, and if no path is left all fields are selected, so that an absent or empty ?fields=
+// parameter split at commas marshals v like Marshal.
+func MarshalFields(v any, fields []string) ([]byte, error) {
+	set := make(fieldSet)
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		if err := set.add(field); err != nil {
+			return nil, err
+		}
+	}
+	if len(set) == 0 {
+		return Marshal(v)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.IsValid() {
+		if err := checkFields(rv.Type(), set, ""); err != nil {
+			return nil, err
+		}
+	}
+	var e encoder
+	if err := e.encodeFields(rv, set); err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+// fieldSet is a set of selected struct fields by JSON name. A field with a nil set is selected
+// with all of its fields, and a field with a non-nil set with only the fields in that set.
+type fieldSet map[string]fieldSet
+
+// add adds the field path, a dot-separated list of field names, to s.
+func (s fieldSet) add(path string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		if name == "" {
+			return fmt.Errorf("jsontype: invalid field %q", path)
+		}
+		sub, ok := s[name]
+		switch {
+		case i == len(names)-1:
+			s[name] = nil
+		case ok && sub == nil:
+			return nil // the whole field is already selected
+		case !ok:
+			sub = make(fieldSet)
+			s[name] = sub
+		}
+		s = sub
+	}
+	return nil
+}
+
+// checkFields checks that the fields in set are fields of values of type t, which is the type of
+// the value at the JSON Pointer path.
+func checkFields(t reflect.Type, set fieldSet, path string) error {
+	for {
+		switch {
+		case typeinfo.IsNull(t):
+			t = t.Field(0).Type
+		case t.Kind() == reflect.Pointer, t.Kind() == reflect.Slice, t.Kind() == reflect.Array:
+			t = t.Elem()
+		default:
+			return checkStructFields(t, set, path)
+		}
+	}
+}
+
+func checkStructFields(t reflect.Type, set fieldSet, path string) error {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []typeinfo.Field
+	if t.Kind() == reflect.Struct && !implementsMarshaler(reflect.New(t).Elem()) {
+		fields = typeinfo.Fields(t, "json")
+	}
+	for _, name := range names {
+		fpath := appendPointer(path, name)
+		f, ok := findField(fields, name)
+		if !ok {
+			return &UnknownFieldError{Path: fpath}
+		}
+		if sub := set[name]; sub != nil {
+			if err := checkFields(f.StructField.Type, sub, fpath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findField returns the field named name. Unlike lookupField, it only matches the exact name.
+func findField(fields []typeinfo.Field, name string) (typeinfo.Field, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return typeinfo.Field{}, false
+}
+
+// encodeFields writes the encoding of v with only the struct fields in set, which have been checked
+// by checkFields.
+func (e *encoder) encodeFields(v reflect.Value, set fieldSet) error {
+	if !v.IsValid() {
+		e.WriteString("null")
+		return nil
+	}
+	if value, valid, present, ok := typeinfo.NullParts(v); ok {
+		if !present.Bool() || !valid.Bool() {
+			e.WriteString("null")
+			return nil
+		}
+		return e.encodeFields(value, set)
+	}
+	if implementsMarshaler(v) {
+		return e.marshal(v)
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			e.WriteString("null")
+			return nil
+		}
+		return e.encodeFields(v.Elem(), set)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			e.WriteString("null")
+			return nil
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return e.marshal(v)
+		}
+		e.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				e.WriteByte(',')
+			}
+			if err := e.encodeFields(v.Index(i), set); err != nil {
+				return err
+			}
+		}
+		e.WriteByte(']')
+		return nil
+	case reflect.Struct:
+		return e.encodeStruct(v, set)
+	}
+	return e.encode(v)
+}
@@ -0,0 +1,176 @@
+package jsontype
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Test the MarshalFields function
+func TestMarshalFields(t *testing.T) {
+	person := marshalPerson{
+		Name:     NullString{String: "John", Valid: true, Present: true},
+		Age:      NullInt{Present: true},
+		Address:  &marshalAddress{City: NullString{String: "Boston", Valid: true, Present: true}, Zip: NullString{Present: true}},
+		Contacts: []marshalAddress{{City: NullString{String: "Paris", Valid: true, Present: true}}, {Zip: NullString{String: "1234", Valid: true, Present: true}}},
+		Extra:    Null[marshalAddress]{Present: true},
+		Count:    3,
+		Zero:     time.Date(2024, 01, 01, 00, 00, 00, 00, time.UTC),
+	}
+	tests := []struct {
+		name        string
+		input       any
+		fields      []string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "Top-level fields",
+			input:    person,
+			fields:   []string{"name", "age", "count"},
+			expected: `{"name":"John","age":null,"count":"3"}`,
+		},
+		{
+			name:     "Absent field",
+			input:    person,
+			fields:   []string{"name", "born"},
+			expected: `{"name":"John"}`,
+		},
+		{
+			name:     "Nested field",
+			input:    &person,
+			fields:   []string{"name", "address.city"},
+			expected: `{"name":"John","address":{"city":"Boston"}}`,
+		},
+		{
+			name:     "Whole struct",
+			input:    person,
+			fields:   []string{"address.city", "address"},
+			expected: `{"address":{"city":"Boston","zip":null}}`,
+		},
+		{
+			name:     "Slice elements",
+			input:    person,
+			fields:   []string{"contacts.city"},
+			expected: `{"contacts":[{"city":"Paris"},{}]}`,
+		},
+		{
+			name:     "Null struct",
+			input:    person,
+			fields:   []string{"extra.city", " zero "},
+			expected: `{"extra":null,"zero":"2024-01-01T00:00:00Z"}`,
+		},
+		{
+			name:     "Slice of structs",
+			input:    []marshalAddress{{City: NullString{String: "Paris", Valid: true, Present: true}, Zip: NullString{Present: true}}},
+			fields:   []string{"zip"},
+			expected: `[{"zip":null}]`,
+		},
+		{
+			name:     "Scalar",
+			input:    5,
+			expected: `5`,
+		},
+		{
+			name:     "Nil",
+			input:    nil,
+			expected: `null`,
+		},
+		{
+			name:     "Slice of scalars",
+			input:    []string{"a", "b"},
+			expected: `["a","b"]`,
+		},
+		{
+			name:     "Bytes",
+			input:    []byte("hi"),
+			expected: `"aGk="`,
+		},
+		{
+			name:     "Map",
+			input:    map[string]NullInt{"a": {Int: 1, Valid: true, Present: true}},
+			expected: `{"a":1}`,
+		},
+		{
+			name:     "No fields",
+			input:    person.Address,
+			expected: `{"city":"Boston","zip":null}`,
+		},
+		{
+			name:     "Empty fields",
+			input:    person.Address,
+			fields:   []string{"", " "},
+			expected: `{"city":"Boston","zip":null}`,
+		},
+		{
+			name:        "Unknown field",
+			input:       person,
+			fields:      []string{"name", "nickname"},
+			expectedErr: "jsontype: unknown field /nickname",
+		},
+		{
+			name:        "Unknown nested field",
+			input:       marshalPerson{},
+			fields:      []string{"extra.street"},
+			expectedErr: "jsontype: unknown field /extra/street",
+		},
+		{
+			name:        "Field of a scalar",
+			input:       person,
+			fields:      []string{"name.first"},
+			expectedErr: "jsontype: unknown field /name/first",
+		},
+		{
+			name:        "Field of a scalar value",
+			input:       5,
+			fields:      []string{"name"},
+			expectedErr: "jsontype: unknown field /name",
+		},
+		{
+			name:        "Field of a marshaler",
+			input:       person,
+			fields:      []string{"born.year"},
+			expectedErr: "jsontype: unknown field /born/year",
+		},
+		{
+			name:        "Ignored field",
+			input:       person,
+			fields:      []string{"Internal"},
+			expectedErr: "jsontype: unknown field /Internal",
+		},
+		{
+			name:        "Invalid field",
+			input:       person,
+			fields:      []string{"address..city"},
+			expectedErr: `jsontype: invalid field "address..city"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalFields(tt.input, tt.fields)
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Errorf("MarshalFields() error = %v, expected %s", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("MarshalFields() error = %v", err)
+				return
+			}
+			if string(got) != tt.expected {
+				t.Errorf("MarshalFields() = %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that MarshalFields reports unknown fields as an UnknownFieldError
+func TestMarshalFields_UnknownFieldError(t *testing.T) {
+	_, err := MarshalFields(marshalPerson{}, []string{"address.street"})
+	var ue *UnknownFieldError
+	if !errors.As(err, &ue) || ue.Path != "/address/street" {
+		t.Errorf("MarshalFields() error = %v, expected an *UnknownFieldError for /address/street", err)
+	}
+}
@@ -0,0 +1,90 @@
+package jsontype
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// FormError describes a form value that cannot be decoded into its field.
+type FormError struct {
+	Key   string // Key is the form key of the field
+	Value string // Value is the value that cannot be decoded
+	Err   error  // Err is the error returned by the parser
+}
+
+// Error implements the error interface.
+func (e *FormError) Error() string {
+	return fmt.Sprintf("jsontype: cannot decode %q for form key %q: %v", e.Value, e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FormError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeValues decodes the URL query or form values into the Null fields of the struct pointed to
+// by dst, matching the keys by the name in the form struct tag or by the Go field name.
+//
+// Fields whose key does not appear in values are left absent. Fields whose key appears are set to
+// present, and are decoded from the first value of the key by their UnmarshalText method: a value
+// equal to NullText is null, and an empty value is an empty string for NullString and an error for
+// most other types. Fields of type Null[[]T] are decoded from all values of the key, one element
+// per value, unless the only value is NullText. Fields that are not Null types are ignored.
+//
+// DecodeValues decodes all fields it can and returns an Errors list with a *FormError for every
+// value that cannot be parsed; the fields of those values are left unchanged.
+func DecodeValues(values url.Values, dst any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("jsontype: DecodeValues destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+	dv = dv.Elem()
+
+	var errs Errors
+	for _, f := range typeinfo.Fields(dv.Type(), "form") {
+		if !typeinfo.IsNull(f.StructField.Type) {
+			continue
+		}
+		vals, found := values[f.Name]
+		if !found {
+			continue
+		}
+		fv, ok := typeinfo.SettableFieldByIndex(dv, f.Index)
+		if !ok {
+			errs = append(errs, &FormError{Key: f.Name, Err: fmt.Errorf("cannot set embedded pointer to unexported struct in %s", dv.Type())})
+			continue
+		}
+		value, valid, present, _ := typeinfo.NullParts(fv)
+		if value.Kind() == reflect.Slice && (len(vals) != 1 || vals[0] != NullText) {
+			s := reflect.MakeSlice(value.Type(), len(vals), len(vals))
+			failed := false
+			for i, val := range vals {
+				if err := typeinfo.ParseText(s.Index(i), val); err != nil {
+					errs = append(errs, &FormError{Key: f.Name, Value: val, Err: err})
+					failed = true
+				}
+			}
+			if !failed {
+				value.Set(s)
+				valid.SetBool(true)
+				present.SetBool(true)
+			}
+			continue
+		}
+		var val string
+		if len(vals) > 0 {
+			val = vals[0]
+		}
+		if err := fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(val)); err != nil {
+			errs = append(errs, &FormError{Key: f.Name, Value: val, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package jsontype
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type formFilter struct {
+	Query  NullString     `form:"q"`
+	Limit  NullInt        `form:"limit"`
+	Active NullBool       `form:"active"`
+	Since  NullTime       `form:"since"`
+	Score  Null[float64]  `form:"score"`
+	IDs    Null[[]int]    `form:"id"`
+	Tags   Null[[]string] `form:"tag"`
+	Skip   NullString     `form:"-"`
+	Other  string         `form:"other"`
+}
+
+// Test the DecodeValues function
+func TestDecodeValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		expected  formFilter
+		expectErr string
+	}{
+		{
+			name:     "Absent",
+			query:    "",
+			expected: formFilter{},
+		},
+		{
+			name:  "Values",
+			query: "q=foo&limit=10&active=true&since=2023-01-02T03:04:05Z&score=1.5&other=x",
+			expected: formFilter{
+				Query:  NullString{String: "foo", Valid: true, Present: true},
+				Limit:  NullInt{Int: 10, Valid: true, Present: true},
+				Active: NullBool{Bool: true, Valid: true, Present: true},
+				Since:  NullTime{Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true, Present: true},
+				Score:  Null[float64]{Value: 1.5, Valid: true, Present: true},
+			},
+		},
+		{
+			name:  "Empty and null",
+			query: "q=&limit=null&id=null",
+			expected: formFilter{
+				Query: NullString{Valid: true, Present: true},
+				Limit: NullInt{Present: true},
+				IDs:   Null[[]int]{Present: true},
+			},
+		},
+		{
+			name:  "Repeated keys",
+			query: "id=1&id=2&tag=a&q=first&q=second",
+			expected: formFilter{
+				Query: NullString{String: "first", Valid: true, Present: true},
+				IDs:   Null[[]int]{Value: []int{1, 2}, Valid: true, Present: true},
+				Tags:  Null[[]string]{Value: []string{"a"}, Valid: true, Present: true},
+			},
+		},
+		{
+			name:  "Ignored keys",
+			query: "Skip=x&unknown=y",
+		},
+		{
+			name:  "Parse errors",
+			query: "q=foo&limit=ten&id=1&id=x&active=",
+			expected: formFilter{
+				Query: NullString{String: "foo", Valid: true, Present: true},
+			},
+			expectErr: `jsontype: cannot decode "ten" for form key "limit": strconv.ParseInt: parsing "ten": invalid syntax; ` +
+				`jsontype: cannot decode "" for form key "active": strconv.ParseBool: parsing "": invalid syntax; ` +
+				`jsontype: cannot decode "x" for form key "id": strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var f formFilter
+			err = DecodeValues(values, &f)
+			if (err != nil) != (tt.expectErr != "") || (err != nil && err.Error() != tt.expectErr) {
+				t.Errorf("DecodeValues() error = %v, expected %v", err, tt.expectErr)
+				return
+			}
+			if !reflect.DeepEqual(f, tt.expected) {
+				t.Errorf("DecodeValues() = %+v, expected %+v", f, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that DecodeValues reports parse errors as FormErrors
+func TestDecodeValues_FormError(t *testing.T) {
+	var f formFilter
+	err := DecodeValues(url.Values{"limit": {"ten"}}, &f)
+	var fe *FormError
+	if !errors.As(err, &fe) || fe.Key != "limit" || fe.Value != "ten" {
+		t.Errorf("DecodeValues() error = %v, expected a FormError for key limit", err)
+	}
+	if err := DecodeValues(url.Values{}, f); err == nil {
+		t.Errorf("DecodeValues() error = nil, expected an error for a non-pointer destination")
+	}
+}
@@ -0,0 +1,207 @@
+// Package httppatch decodes the bodies of PATCH requests into structs containing the Null types of
+// package jsontype and reports the errors as problem details (RFC 9457).
+//
+// A handler typically decodes the request with Decode and writes any error with WriteProblem:
+//
+//	var patch PersonPatch
+//	if err := httppatch.Decode(r, &patch); err != nil {
+//		httppatch.WriteProblem(w, err)
+//		return
+//	}
+package httppatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/mbe81/jsontype"
+)
+
+// DefaultMaxBytes is the default maximum size of a request body accepted by Decode.
+const DefaultMaxBytes = 1 << 20
+
+// The media types accepted by Decode.
+const (
+	MediaTypeJSON       = "application/json"
+	MediaTypeMergePatch = "application/merge-patch+json"
+)
+
+var (
+	// ErrUnsupportedMediaType is returned by Decode if the request has another content type than
+	// application/json or application/merge-patch+json.
+	ErrUnsupportedMediaType = errors.New("httppatch: unsupported media type")
+	// ErrBodyTooLarge is returned by Decode if the request body exceeds the maximum size.
+	ErrBodyTooLarge = errors.New("httppatch: request body too large")
+)
+
+// Option configures Decode.
+type Option func(*options)
+
+type options struct {
+	maxBytes      int64
+	unmarshalOpts []jsontype.UnmarshalOption
+}
+
+// MaxBytes sets the maximum size of the request body to n bytes, instead of DefaultMaxBytes.
+func MaxBytes(n int64) Option {
+	return func(o *options) { o.maxBytes = n }
+}
+
+// DisallowUnknownFields makes Decode reject object members that do not match a struct field.
+func DisallowUnknownFields() Option {
+	return func(o *options) { o.unmarshalOpts = append(o.unmarshalOpts, jsontype.DisallowUnknownFields()) }
+}
+
+// Decode decodes the JSON body of r into the struct pointed to by dst and validates it.
+//
+// The content type of r must be application/json or application/merge-patch+json, and the body must
+// not exceed DefaultMaxBytes or the size set by the MaxBytes option. The body is decoded with
+// jsontype.Unmarshal, which reports all fields that cannot be decoded at once. If it is decoded,
+// dst is validated with its Validate method if it has one, such as a patch type generated by
+// jsontype-gen, and with jsontype.Validate otherwise.
+//
+// Decode returns ErrUnsupportedMediaType or ErrBodyTooLarge, possibly wrapped, if the request is
+// rejected, and the error of jsontype.Unmarshal or of the validation otherwise.
+func Decode(r *http.Request, dst any, opts ...Option) error {
+	o := options{maxBytes: DefaultMaxBytes}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != MediaTypeJSON && mediaType != MediaTypeMergePatch) {
+		return fmt.Errorf("%w: %q", ErrUnsupportedMediaType, r.Header.Get("Content-Type"))
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, o.maxBytes))
+	if err != nil {
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, mbe.Limit)
+		}
+		return err
+	}
+
+	if err := jsontype.Unmarshal(body, dst, o.unmarshalOpts...); err != nil {
+		return err
+	}
+	if v, ok := dst.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return jsontype.Validate(dst)
+}
+
+// Problem is a problem details object (RFC 9457) describing why a request was rejected.
+type Problem struct {
+	Type   string       `json:"type,omitempty"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes a field of the request body that cannot be decoded or is invalid. It is the
+// member type of the errors extension of Problem.
+type FieldError struct {
+	Pointer string `json:"pointer"` // Pointer is the JSON Pointer (RFC 6901) to the field
+	Detail  string `json:"detail"`
+}
+
+// NewProblem returns the problem details for err, an error returned by Decode:
+//
+//   - ErrUnsupportedMediaType is reported as 415 Unsupported Media Type,
+//   - ErrBodyTooLarge as 413 Request Entity Too Large,
+//   - syntax errors as 400 Bad Request,
+//   - values that cannot be decoded and unknown fields as 400 Bad Request with a FieldError for
+//     every field,
+//   - validation errors as 422 Unprocessable Entity with a FieldError for every field,
+//   - and any other error as 500 Internal Server Error, without exposing its message.
+func NewProblem(err error) *Problem {
+	var se *json.SyntaxError
+	switch {
+	case errors.Is(err, ErrUnsupportedMediaType):
+		return newProblem(http.StatusUnsupportedMediaType, "The content type must be "+MediaTypeJSON+" or "+MediaTypeMergePatch+".")
+	case errors.Is(err, ErrBodyTooLarge):
+		return newProblem(http.StatusRequestEntityTooLarge, "The request body is too large.")
+	case errors.As(err, &se):
+		return newProblem(http.StatusBadRequest, "The request body is not valid JSON.")
+	}
+
+	errs := []error{err}
+	if list, ok := err.(jsontype.Errors); ok {
+		errs = list
+	}
+	var fields []FieldError
+	status := http.StatusUnprocessableEntity
+	for _, err := range errs {
+		var de *jsontype.DecodeError
+		var ue *jsontype.UnknownFieldError
+		var ve *jsontype.ValidationError
+		switch {
+		case errors.As(err, &de):
+			status = http.StatusBadRequest
+			detail := "must be " + article(de.Expected)
+			if de.Nullable {
+				detail += " or null"
+			}
+			fields = append(fields, FieldError{Pointer: de.Path, Detail: detail})
+		case errors.As(err, &ue):
+			status = http.StatusBadRequest
+			fields = append(fields, FieldError{Pointer: ue.Path, Detail: "is not a known field"})
+		case errors.As(err, &ve):
+			fields = append(fields, FieldError{Pointer: ve.Path, Detail: ruleDetail(ve.Rule)})
+		default:
+			return newProblem(http.StatusInternalServerError, "")
+		}
+	}
+	p := newProblem(status, "The request body is invalid.")
+	if status == http.StatusBadRequest {
+		p.Detail = "The request body cannot be decoded."
+	}
+	p.Errors = fields
+	return p
+}
+
+// WriteProblem writes the problem details for err, an error returned by Decode, to w as an
+// application/problem+json response, as described by NewProblem.
+func WriteProblem(w http.ResponseWriter, err error) {
+	p := NewProblem(err)
+	if p.Status == http.StatusUnsupportedMediaType {
+		w.Header().Set("Accept-Patch", MediaTypeJSON+", "+MediaTypeMergePatch)
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+func newProblem(status int, detail string) *Problem {
+	return &Problem{Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+// article returns the kind of JSON value kind, such as "integer", with its indefinite article.
+func article(kind string) string {
+	switch kind {
+	case "integer", "array", "object":
+		return "an " + kind
+	case "value":
+		return "a valid value"
+	}
+	return "a " + kind
+}
+
+// ruleDetail returns the detail of a FieldError for a violated jsontype tag rule.
+func ruleDetail(rule string) string {
+	switch rule {
+	case "required":
+		return "is required"
+	case "notnull":
+		return "must not be null"
+	case "forbidden":
+		return "is not allowed"
+	}
+	return "violates rule " + rule
+}
@@ -0,0 +1,202 @@
+package httppatch
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mbe81/jsontype"
+)
+
+type personPatch struct {
+	Name jsontype.NullString `json:"name" jsontype:"notnull"`
+	Age  jsontype.NullUint8  `json:"age"`
+	ID   jsontype.NullInt    `json:"id" jsontype:"forbidden"`
+}
+
+type validatedPatch struct {
+	Name jsontype.NullString `json:"name"`
+}
+
+func (p validatedPatch) Validate() error {
+	if p.Name.State() != jsontype.StateSet {
+		return jsontype.Errors{&jsontype.ValidationError{Path: "/name", Rule: "required"}}
+	}
+	return nil
+}
+
+func newRequest(contentType, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPatch, "/people/1", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+// Test the Decode function
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		opts        []Option
+		expected    personPatch
+		expectedErr error
+	}{
+		{
+			name:        "JSON",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name":"John","age":null}`,
+			expected: personPatch{
+				Name: jsontype.NullString{String: "John", Valid: true, Present: true},
+				Age:  jsontype.NullUint8{Present: true},
+			},
+		},
+		{
+			name:        "Merge patch",
+			contentType: "application/merge-patch+json",
+			body:        `{"age":42,"nickname":"J"}`,
+			expected:    personPatch{Age: jsontype.NullUint8{Uint8: 42, Valid: true, Present: true}},
+		},
+		{
+			name:        "Missing content type",
+			body:        `{}`,
+			expectedErr: ErrUnsupportedMediaType,
+		},
+		{
+			name:        "Unsupported content type",
+			contentType: "text/plain",
+			body:        `{}`,
+			expectedErr: ErrUnsupportedMediaType,
+		},
+		{
+			name:        "Body too large",
+			contentType: "application/json",
+			body:        `{"name":"John"}`,
+			opts:        []Option{MaxBytes(8)},
+			expectedErr: ErrBodyTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p personPatch
+			err := Decode(newRequest(tt.contentType, tt.body), &p, tt.opts...)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Decode() error = %v, expected %v", err, tt.expectedErr)
+				return
+			}
+			if p != tt.expected {
+				t.Errorf("Decode() = %+v, expected %+v", p, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that Decode validates the decoded value
+func TestDecode_Validate(t *testing.T) {
+	var p personPatch
+	err := Decode(newRequest("application/json", `{"name":null,"id":1}`), &p)
+	var ve *jsontype.ValidationError
+	if !errors.As(err, &ve) || ve.Path != "/name" {
+		t.Errorf("Decode() error = %v, expected a *jsontype.ValidationError for /name", err)
+	}
+
+	var vp validatedPatch
+	err = Decode(newRequest("application/json", `{}`), &vp)
+	if !errors.As(err, &ve) || ve.Rule != "required" {
+		t.Errorf("Decode() error = %v, expected the error of the Validate method", err)
+	}
+}
+
+// Test the WriteProblem function
+func TestWriteProblem(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		opts     []Option
+		expected string
+		status   int
+	}{
+		{
+			name:     "Syntax error",
+			body:     `{"name":`,
+			status:   http.StatusBadRequest,
+			expected: `{"title":"Bad Request","status":400,"detail":"The request body is not valid JSON."}`,
+		},
+		{
+			name:   "Decode errors",
+			body:   `{"name":1,"age":256,"nickname":"J"}`,
+			opts:   []Option{DisallowUnknownFields()},
+			status: http.StatusBadRequest,
+			expected: `{"title":"Bad Request","status":400,"detail":"The request body cannot be decoded.","errors":[` +
+				`{"pointer":"/name","detail":"must be a string or null"},` +
+				`{"pointer":"/age","detail":"must be an integer or null"},` +
+				`{"pointer":"/nickname","detail":"is not a known field"}]}`,
+		},
+		{
+			name:   "Validation errors",
+			body:   `{"name":null,"id":1}`,
+			status: http.StatusUnprocessableEntity,
+			expected: `{"title":"Unprocessable Entity","status":422,"detail":"The request body is invalid.","errors":[` +
+				`{"pointer":"/name","detail":"must not be null"},` +
+				`{"pointer":"/id","detail":"is not allowed"}]}`,
+		},
+		{
+			name:     "Body too large",
+			body:     `{"name":"John"}`,
+			opts:     []Option{MaxBytes(8)},
+			status:   http.StatusRequestEntityTooLarge,
+			expected: `{"title":"Request Entity Too Large","status":413,"detail":"The request body is too large."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p personPatch
+			err := Decode(newRequest("application/json", tt.body), &p, tt.opts...)
+			w := httptest.NewRecorder()
+			WriteProblem(w, err)
+			if w.Code != tt.status {
+				t.Errorf("WriteProblem() status = %d, expected %d", w.Code, tt.status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("WriteProblem() Content-Type = %s, expected application/problem+json", ct)
+			}
+			if body := strings.TrimSpace(w.Body.String()); body != tt.expected {
+				t.Errorf("WriteProblem() body = %s, expected %s", body, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the problem details of errors that are not returned by Decode
+func TestNewProblem(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected *Problem
+	}{
+		{
+			name:     "Unsupported media type",
+			err:      ErrUnsupportedMediaType,
+			expected: &Problem{Title: "Unsupported Media Type", Status: 415, Detail: "The content type must be application/json or application/merge-patch+json."},
+		},
+		{
+			name:     "Other error",
+			err:      errors.New("database is down"),
+			expected: &Problem{Title: "Internal Server Error", Status: 500},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if p := NewProblem(tt.err); !reflect.DeepEqual(p, tt.expected) {
+				t.Errorf("NewProblem() = %+v, expected %+v", p, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Package typeinfo provides the reflection helpers shared by the jsontype packages.
+package typeinfo
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// pkgPath is the import path of the package declaring the Null types.
+const pkgPath = "github.com/mbe81/jsontype"
+
+// IsNull reports whether t is one of the Null types of package jsontype.
+func IsNull(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == pkgPath && t.NumField() == 3 &&
+		t.Field(1).Name == "Valid" && t.Field(2).Name == "Present"
+}
+
+// NullParts returns the wrapped value and the Valid and Present fields of v if v is one of the
+// Null types of package jsontype.
+func NullParts(v reflect.Value) (value, valid, present reflect.Value, ok bool) {
+	if !IsNull(v.Type()) {
+		return reflect.Value{}, reflect.Value{}, reflect.Value{}, false
+	}
+	return v.Field(0), v.Field(1), v.Field(2), true
+}
+
+// Field is a struct field as seen by encoding/json: exported fields only, with embedded structs
+// and pointers to structs flattened and the name taken from the struct tag if present. Of the
+// fields with the same name, only the dominant field is kept, as by encoding/json.
+type Field struct {
+	Name        string // Name is the name from the struct tag, or the Go field name
+	Index       []int  // Index is the index sequence for FieldByIndex
+	StructField reflect.StructField
+	Options     string // Options are the tag options following the name
+}
+
+// HasOption reports whether the tag options of f contain opt.
+func (f Field) HasOption(opt string) bool {
+	for _, o := range strings.Split(f.Options, ",") {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+type fieldsKey struct {
+	t   reflect.Type
+	key string
+}
+
+var fieldCache sync.Map // map[fieldsKey][]Field
+
+// Fields returns the fields of struct type t, using the struct tag key for naming.
+func Fields(t reflect.Type, key string) []Field {
+	k := fieldsKey{t, key}
+	if f, ok := fieldCache.Load(k); ok {
+		return f.([]Field)
+	}
+	fields := appendFields(nil, t, key, nil, map[reflect.Type]bool{t: true})
+	f, _ := fieldCache.LoadOrStore(k, dominantFields(fields, key))
+	return f.([]Field)
+}
+
+// dominantFields returns the fields that are not hidden by another field with the same name,
+// following the rules of encoding/json: the shallowest field wins, then the field named by the
+// struct tag, and names that are still ambiguous are dropped.
+func dominantFields(fields []Field, key string) []Field {
+	byName := make(map[string][]Field, len(fields))
+	for _, f := range fields {
+		byName[f.Name] = append(byName[f.Name], f)
+	}
+	dominant := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if d, ok := dominantField(byName[f.Name], key); ok && equalIndex(d.Index, f.Index) {
+			dominant = append(dominant, f)
+		}
+	}
+	return dominant
+}
+
+// dominantField returns the field of fields, which have the same name, that hides the others, or
+// false if there is none.
+func dominantField(fields []Field, key string) (Field, bool) {
+	depth := len(fields[0].Index)
+	for _, f := range fields {
+		if len(f.Index) < depth {
+			depth = len(f.Index)
+		}
+	}
+	var shallowest, tagged []Field
+	for _, f := range fields {
+		if len(f.Index) != depth {
+			continue
+		}
+		shallowest = append(shallowest, f)
+		if name, _, _ := strings.Cut(f.StructField.Tag.Get(key), ","); name != "" {
+			tagged = append(tagged, f)
+		}
+	}
+	switch {
+	case len(shallowest) == 1:
+		return shallowest[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	}
+	return Field{}, false
+}
+
+// equalIndex reports whether the index sequences a and b are equal.
+func equalIndex(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// appendFields appends the fields of t to fields. Seen holds the embedded types being flattened,
+// so that a type embedding a pointer to itself is not flattened again.
+func appendFields(fields []Field, t reflect.Type, key string, index []int, seen map[reflect.Type]bool) []Field {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get(key)
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		idx := append(append([]int(nil), index...), i)
+		if et := sf.Type; sf.Anonymous && name == "" {
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct && !IsNull(et) {
+				if !seen[et] {
+					seen[et] = true
+					fields = appendFields(fields, et, key, idx, seen)
+					delete(seen, et)
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, Field{Name: name, Index: idx, StructField: sf, Options: opts})
+	}
+	return fields
+}
+
+// FieldByIndex returns the field of struct v with the index sequence index, like
+// reflect.Value.FieldByIndex. If the field is in an embedded struct behind a nil pointer, it
+// returns the zero value of the field, which cannot be set, and false.
+func FieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	f, err := v.FieldByIndexErr(index)
+	if err != nil {
+		return reflect.Zero(v.Type().FieldByIndex(index).Type), false
+	}
+	return f, true
+}
+
+// SettableFieldByIndex returns the field of struct v with the index sequence index like
+// FieldByIndex, allocating the embedded structs behind nil pointers on the way. It returns false
+// if such a pointer cannot be set because its field is unexported.
+func SettableFieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ParseText sets v, which must be settable, from its text representation s. Values implementing
+// encoding.TextUnmarshaler parse themselves; strings, bools, integers and floats are parsed with
+// package strconv.
+func ParseText(v reflect.Value, s string) error {
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot parse text into %s", v.Type())
+	}
+	return nil
+}
+
+// FormatText returns the text representation of v, the inverse of ParseText.
+func FormatText(v reflect.Value) (string, error) {
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("cannot format %s as text", v.Type())
+}
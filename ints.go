@@ -0,0 +1,84 @@
+package jsontype
+
+// The fixed-width integer types and all their methods are generated from a single template.
+//go:generate go run ./cmd/jsontype-gen -null NullInt8,NullInt16,NullInt32,NullInt64,NullUint,NullUint8,NullUint16,NullUint32,NullUint64 -output ints_gen.go
+//go:generate go run ./cmd/jsontype-gen -null NullInt8,NullInt16,NullInt32,NullInt64,NullUint,NullUint8,NullUint16,NullUint32,NullUint64 -jsonv2 -output ints_jsonv2_gen.go
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// unmarshalInt decodes the JSON number data as a signed integer of bitSize bits, rejecting numbers
+// that are out of range or not integers with a *DecodeError. Other JSON values are decoded into dst
+// by json.Unmarshal to report the standard error as the cause of the *DecodeError.
+func unmarshalInt(data []byte, dst any, bitSize int) (int64, error) {
+	data = bytes.TrimSpace(data)
+	if !isNumber(data) {
+		if err := json.Unmarshal(data, dst); err != nil {
+			return 0, newDecodeError(data, "integer", err)
+		}
+		return 0, nil
+	}
+	return parseIntNumber(data, dst, bitSize)
+}
+
+// parseIntNumber parses the JSON number data as a signed integer of bitSize bits for dst, rejecting
+// numbers that are out of range or not integers with a *DecodeError naming the bound.
+func parseIntNumber(data []byte, dst any, bitSize int) (int64, error) {
+	n, err := strconv.ParseInt(string(data), 10, bitSize)
+	if err != nil {
+		if err.(*strconv.NumError).Err != strconv.ErrRange {
+			return 0, newDecodeError(data, "integer", errors.New("not an integer"))
+		}
+		if data[0] == '-' {
+			return 0, newDecodeError(data, "integer", fmt.Errorf("out of range for %s, minimum is %d", typeName(dst), int64(-1)<<(bitSize-1)))
+		}
+		return 0, newDecodeError(data, "integer", fmt.Errorf("out of range for %s, maximum is %d", typeName(dst), uint64(1)<<(bitSize-1)-1))
+	}
+	return n, nil
+}
+
+// unmarshalUint decodes the JSON number data as an unsigned integer of bitSize bits, rejecting
+// numbers that are out of range or not integers with a *DecodeError. Other JSON values are decoded
+// into dst by json.Unmarshal to report the standard error as the cause of the *DecodeError.
+func unmarshalUint(data []byte, dst any, bitSize int) (uint64, error) {
+	data = bytes.TrimSpace(data)
+	if !isNumber(data) {
+		if err := json.Unmarshal(data, dst); err != nil {
+			return 0, newDecodeError(data, "integer", err)
+		}
+		return 0, nil
+	}
+	return parseUintNumber(data, dst, bitSize)
+}
+
+// parseUintNumber parses the JSON number data as an unsigned integer of bitSize bits for dst,
+// rejecting numbers that are out of range or not integers with a *DecodeError naming the bound.
+func parseUintNumber(data []byte, dst any, bitSize int) (uint64, error) {
+	if bytes.ContainsAny(data, ".eE") {
+		return 0, newDecodeError(data, "integer", errors.New("not an integer"))
+	}
+	if data[0] == '-' {
+		return 0, newDecodeError(data, "integer", fmt.Errorf("out of range for %s, minimum is 0", typeName(dst)))
+	}
+	n, err := strconv.ParseUint(string(data), 10, bitSize)
+	if err != nil {
+		return 0, newDecodeError(data, "integer", fmt.Errorf("out of range for %s, maximum is %d", typeName(dst), uint64(1<<bitSize-1)))
+	}
+	return n, nil
+}
+
+// typeName returns the name of the type pointed to by dst.
+func typeName(dst any) string {
+	return reflect.TypeOf(dst).Elem().String()
+}
+
+// isNumber reports whether data is a valid JSON number.
+func isNumber(data []byte) bool {
+	return len(data) > 0 && (data[0] == '-' || data[0] >= '0' && data[0] <= '9') && json.Valid(data)
+}
@@ -0,0 +1,1171 @@
+// Code generated by jsontype-gen -null NullInt8,NullInt16,NullInt32,NullInt64,NullUint,NullUint8,NullUint16,NullUint32,NullUint64; DO NOT EDIT.
+
+package jsontype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+)
+
+// NullInt8 represents an int8 that may be null or may be absent.
+// NullInt8 implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for int8 or that are not integers are rejected.
+type NullInt8 struct {
+	Int8    int8
+	Valid   bool // Valid is true if Int8 is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ni *NullInt8) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		ni.Int8, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, ni) {
+		return nil
+	}
+	n, err := unmarshalInt(data, &ni.Int8, 8)
+	if err != nil {
+		return err
+	}
+	ni.Int8, ni.Valid, ni.Present = int8(n), true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ni NullInt8) MarshalJSON() ([]byte, error) {
+	if !ni.Present || !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int8)
+}
+
+// IsZero reports whether ni is absent, so that the omitzero tag option omits absent fields.
+func (ni NullInt8) IsZero() bool {
+	return !ni.Present
+}
+
+// Get returns the value of ni and true if ni is set, or the zero value and false otherwise.
+func (ni NullInt8) Get() (int8, bool) {
+	if ni.State() != StateSet {
+		return 0, false
+	}
+	return ni.Int8, true
+}
+
+// State returns the state of ni.
+func (ni NullInt8) State() State {
+	return stateOf(ni.Valid, ni.Present)
+}
+
+// Set sets ni to v.
+func (ni *NullInt8) Set(v int8) {
+	ni.Int8, ni.Valid, ni.Present = v, true, true
+}
+
+// SetNull sets ni to null.
+func (ni *NullInt8) SetNull() {
+	ni.Int8, ni.Valid, ni.Present = 0, false, true
+}
+
+// Unset makes ni absent.
+func (ni *NullInt8) Unset() {
+	*ni = NullInt8{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ni *NullInt8) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		ni.Int8, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseInt(string(text), 10, 8)
+	if err != nil {
+		return err
+	}
+	ni.Int8, ni.Valid, ni.Present = int8(n), true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ni NullInt8) MarshalText() ([]byte, error) {
+	if !ni.Present || !ni.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatInt(int64(ni.Int8), 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (ni *NullInt8) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *int8
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		ni.Int8, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	ni.Int8, ni.Valid, ni.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (ni NullInt8) MarshalYAML() (any, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return ni.Int8, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (ni *NullInt8) Scan(src any) error {
+	v, valid, err := scan[int8](src)
+	if err != nil {
+		return err
+	}
+	ni.Int8, ni.Valid, ni.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ni NullInt8) Value() (driver.Value, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int8), nil
+}
+
+// NullInt16 represents an int16 that may be null or may be absent.
+// NullInt16 implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for int16 or that are not integers are rejected.
+type NullInt16 struct {
+	Int16   int16
+	Valid   bool // Valid is true if Int16 is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ni *NullInt16) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		ni.Int16, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, ni) {
+		return nil
+	}
+	n, err := unmarshalInt(data, &ni.Int16, 16)
+	if err != nil {
+		return err
+	}
+	ni.Int16, ni.Valid, ni.Present = int16(n), true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ni NullInt16) MarshalJSON() ([]byte, error) {
+	if !ni.Present || !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int16)
+}
+
+// IsZero reports whether ni is absent, so that the omitzero tag option omits absent fields.
+func (ni NullInt16) IsZero() bool {
+	return !ni.Present
+}
+
+// Get returns the value of ni and true if ni is set, or the zero value and false otherwise.
+func (ni NullInt16) Get() (int16, bool) {
+	if ni.State() != StateSet {
+		return 0, false
+	}
+	return ni.Int16, true
+}
+
+// State returns the state of ni.
+func (ni NullInt16) State() State {
+	return stateOf(ni.Valid, ni.Present)
+}
+
+// Set sets ni to v.
+func (ni *NullInt16) Set(v int16) {
+	ni.Int16, ni.Valid, ni.Present = v, true, true
+}
+
+// SetNull sets ni to null.
+func (ni *NullInt16) SetNull() {
+	ni.Int16, ni.Valid, ni.Present = 0, false, true
+}
+
+// Unset makes ni absent.
+func (ni *NullInt16) Unset() {
+	*ni = NullInt16{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ni *NullInt16) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		ni.Int16, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseInt(string(text), 10, 16)
+	if err != nil {
+		return err
+	}
+	ni.Int16, ni.Valid, ni.Present = int16(n), true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ni NullInt16) MarshalText() ([]byte, error) {
+	if !ni.Present || !ni.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatInt(int64(ni.Int16), 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (ni *NullInt16) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *int16
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		ni.Int16, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	ni.Int16, ni.Valid, ni.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (ni NullInt16) MarshalYAML() (any, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return ni.Int16, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (ni *NullInt16) Scan(src any) error {
+	v, valid, err := scan[int16](src)
+	if err != nil {
+		return err
+	}
+	ni.Int16, ni.Valid, ni.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ni NullInt16) Value() (driver.Value, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int16), nil
+}
+
+// NullInt32 represents an int32 that may be null or may be absent.
+// NullInt32 implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for int32 or that are not integers are rejected.
+type NullInt32 struct {
+	Int32   int32
+	Valid   bool // Valid is true if Int32 is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ni *NullInt32) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		ni.Int32, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, ni) {
+		return nil
+	}
+	n, err := unmarshalInt(data, &ni.Int32, 32)
+	if err != nil {
+		return err
+	}
+	ni.Int32, ni.Valid, ni.Present = int32(n), true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ni NullInt32) MarshalJSON() ([]byte, error) {
+	if !ni.Present || !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int32)
+}
+
+// IsZero reports whether ni is absent, so that the omitzero tag option omits absent fields.
+func (ni NullInt32) IsZero() bool {
+	return !ni.Present
+}
+
+// Get returns the value of ni and true if ni is set, or the zero value and false otherwise.
+func (ni NullInt32) Get() (int32, bool) {
+	if ni.State() != StateSet {
+		return 0, false
+	}
+	return ni.Int32, true
+}
+
+// State returns the state of ni.
+func (ni NullInt32) State() State {
+	return stateOf(ni.Valid, ni.Present)
+}
+
+// Set sets ni to v.
+func (ni *NullInt32) Set(v int32) {
+	ni.Int32, ni.Valid, ni.Present = v, true, true
+}
+
+// SetNull sets ni to null.
+func (ni *NullInt32) SetNull() {
+	ni.Int32, ni.Valid, ni.Present = 0, false, true
+}
+
+// Unset makes ni absent.
+func (ni *NullInt32) Unset() {
+	*ni = NullInt32{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ni *NullInt32) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		ni.Int32, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseInt(string(text), 10, 32)
+	if err != nil {
+		return err
+	}
+	ni.Int32, ni.Valid, ni.Present = int32(n), true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ni NullInt32) MarshalText() ([]byte, error) {
+	if !ni.Present || !ni.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatInt(int64(ni.Int32), 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (ni *NullInt32) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *int32
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		ni.Int32, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	ni.Int32, ni.Valid, ni.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (ni NullInt32) MarshalYAML() (any, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return ni.Int32, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (ni *NullInt32) Scan(src any) error {
+	v, valid, err := scan[int32](src)
+	if err != nil {
+		return err
+	}
+	ni.Int32, ni.Valid, ni.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ni NullInt32) Value() (driver.Value, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int32), nil
+}
+
+// NullInt64 represents an int64 that may be null or may be absent.
+// NullInt64 implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for int64 or that are not integers are rejected.
+type NullInt64 struct {
+	Int64   int64
+	Valid   bool // Valid is true if Int64 is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ni *NullInt64) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		ni.Int64, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, ni) {
+		return nil
+	}
+	n, err := unmarshalInt(data, &ni.Int64, 64)
+	if err != nil {
+		return err
+	}
+	ni.Int64, ni.Valid, ni.Present = n, true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ni NullInt64) MarshalJSON() ([]byte, error) {
+	if !ni.Present || !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int64)
+}
+
+// IsZero reports whether ni is absent, so that the omitzero tag option omits absent fields.
+func (ni NullInt64) IsZero() bool {
+	return !ni.Present
+}
+
+// Get returns the value of ni and true if ni is set, or the zero value and false otherwise.
+func (ni NullInt64) Get() (int64, bool) {
+	if ni.State() != StateSet {
+		return 0, false
+	}
+	return ni.Int64, true
+}
+
+// State returns the state of ni.
+func (ni NullInt64) State() State {
+	return stateOf(ni.Valid, ni.Present)
+}
+
+// Set sets ni to v.
+func (ni *NullInt64) Set(v int64) {
+	ni.Int64, ni.Valid, ni.Present = v, true, true
+}
+
+// SetNull sets ni to null.
+func (ni *NullInt64) SetNull() {
+	ni.Int64, ni.Valid, ni.Present = 0, false, true
+}
+
+// Unset makes ni absent.
+func (ni *NullInt64) Unset() {
+	*ni = NullInt64{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ni *NullInt64) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		ni.Int64, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil {
+		return err
+	}
+	ni.Int64, ni.Valid, ni.Present = n, true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ni NullInt64) MarshalText() ([]byte, error) {
+	if !ni.Present || !ni.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatInt(ni.Int64, 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (ni *NullInt64) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *int64
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		ni.Int64, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	ni.Int64, ni.Valid, ni.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (ni NullInt64) MarshalYAML() (any, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return ni.Int64, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (ni *NullInt64) Scan(src any) error {
+	v, valid, err := scan[int64](src)
+	if err != nil {
+		return err
+	}
+	ni.Int64, ni.Valid, ni.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ni NullInt64) Value() (driver.Value, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int64), nil
+}
+
+// NullUint represents a uint that may be null or may be absent.
+// NullUint implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for uint or that are not integers are rejected.
+type NullUint struct {
+	Uint    uint
+	Valid   bool // Valid is true if Uint is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (nu *NullUint) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		nu.Uint, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, nu) {
+		return nil
+	}
+	n, err := unmarshalUint(data, &nu.Uint, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	nu.Uint, nu.Valid, nu.Present = uint(n), true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (nu NullUint) MarshalJSON() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nu.Uint)
+}
+
+// IsZero reports whether nu is absent, so that the omitzero tag option omits absent fields.
+func (nu NullUint) IsZero() bool {
+	return !nu.Present
+}
+
+// Get returns the value of nu and true if nu is set, or the zero value and false otherwise.
+func (nu NullUint) Get() (uint, bool) {
+	if nu.State() != StateSet {
+		return 0, false
+	}
+	return nu.Uint, true
+}
+
+// State returns the state of nu.
+func (nu NullUint) State() State {
+	return stateOf(nu.Valid, nu.Present)
+}
+
+// Set sets nu to v.
+func (nu *NullUint) Set(v uint) {
+	nu.Uint, nu.Valid, nu.Present = v, true, true
+}
+
+// SetNull sets nu to null.
+func (nu *NullUint) SetNull() {
+	nu.Uint, nu.Valid, nu.Present = 0, false, true
+}
+
+// Unset makes nu absent.
+func (nu *NullUint) Unset() {
+	*nu = NullUint{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (nu *NullUint) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		nu.Uint, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, strconv.IntSize)
+	if err != nil {
+		return err
+	}
+	nu.Uint, nu.Valid, nu.Present = uint(n), true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (nu NullUint) MarshalText() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatUint(uint64(nu.Uint), 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (nu *NullUint) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *uint
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		nu.Uint, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	nu.Uint, nu.Valid, nu.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (nu NullUint) MarshalYAML() (any, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return nu.Uint, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (nu *NullUint) Scan(src any) error {
+	v, valid, err := scan[uint](src)
+	if err != nil {
+		return err
+	}
+	nu.Uint, nu.Valid, nu.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nu NullUint) Value() (driver.Value, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(nu.Uint)
+}
+
+// NullUint8 represents a uint8 that may be null or may be absent.
+// NullUint8 implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for uint8 or that are not integers are rejected.
+type NullUint8 struct {
+	Uint8   uint8
+	Valid   bool // Valid is true if Uint8 is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (nu *NullUint8) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		nu.Uint8, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, nu) {
+		return nil
+	}
+	n, err := unmarshalUint(data, &nu.Uint8, 8)
+	if err != nil {
+		return err
+	}
+	nu.Uint8, nu.Valid, nu.Present = uint8(n), true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (nu NullUint8) MarshalJSON() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nu.Uint8)
+}
+
+// IsZero reports whether nu is absent, so that the omitzero tag option omits absent fields.
+func (nu NullUint8) IsZero() bool {
+	return !nu.Present
+}
+
+// Get returns the value of nu and true if nu is set, or the zero value and false otherwise.
+func (nu NullUint8) Get() (uint8, bool) {
+	if nu.State() != StateSet {
+		return 0, false
+	}
+	return nu.Uint8, true
+}
+
+// State returns the state of nu.
+func (nu NullUint8) State() State {
+	return stateOf(nu.Valid, nu.Present)
+}
+
+// Set sets nu to v.
+func (nu *NullUint8) Set(v uint8) {
+	nu.Uint8, nu.Valid, nu.Present = v, true, true
+}
+
+// SetNull sets nu to null.
+func (nu *NullUint8) SetNull() {
+	nu.Uint8, nu.Valid, nu.Present = 0, false, true
+}
+
+// Unset makes nu absent.
+func (nu *NullUint8) Unset() {
+	*nu = NullUint8{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (nu *NullUint8) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		nu.Uint8, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return err
+	}
+	nu.Uint8, nu.Valid, nu.Present = uint8(n), true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (nu NullUint8) MarshalText() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatUint(uint64(nu.Uint8), 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (nu *NullUint8) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *uint8
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		nu.Uint8, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	nu.Uint8, nu.Valid, nu.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (nu NullUint8) MarshalYAML() (any, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return nu.Uint8, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (nu *NullUint8) Scan(src any) error {
+	v, valid, err := scan[uint8](src)
+	if err != nil {
+		return err
+	}
+	nu.Uint8, nu.Valid, nu.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nu NullUint8) Value() (driver.Value, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return int64(nu.Uint8), nil
+}
+
+// NullUint16 represents a uint16 that may be null or may be absent.
+// NullUint16 implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for uint16 or that are not integers are rejected.
+type NullUint16 struct {
+	Uint16  uint16
+	Valid   bool // Valid is true if Uint16 is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (nu *NullUint16) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		nu.Uint16, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, nu) {
+		return nil
+	}
+	n, err := unmarshalUint(data, &nu.Uint16, 16)
+	if err != nil {
+		return err
+	}
+	nu.Uint16, nu.Valid, nu.Present = uint16(n), true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (nu NullUint16) MarshalJSON() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nu.Uint16)
+}
+
+// IsZero reports whether nu is absent, so that the omitzero tag option omits absent fields.
+func (nu NullUint16) IsZero() bool {
+	return !nu.Present
+}
+
+// Get returns the value of nu and true if nu is set, or the zero value and false otherwise.
+func (nu NullUint16) Get() (uint16, bool) {
+	if nu.State() != StateSet {
+		return 0, false
+	}
+	return nu.Uint16, true
+}
+
+// State returns the state of nu.
+func (nu NullUint16) State() State {
+	return stateOf(nu.Valid, nu.Present)
+}
+
+// Set sets nu to v.
+func (nu *NullUint16) Set(v uint16) {
+	nu.Uint16, nu.Valid, nu.Present = v, true, true
+}
+
+// SetNull sets nu to null.
+func (nu *NullUint16) SetNull() {
+	nu.Uint16, nu.Valid, nu.Present = 0, false, true
+}
+
+// Unset makes nu absent.
+func (nu *NullUint16) Unset() {
+	*nu = NullUint16{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (nu *NullUint16) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		nu.Uint16, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 16)
+	if err != nil {
+		return err
+	}
+	nu.Uint16, nu.Valid, nu.Present = uint16(n), true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (nu NullUint16) MarshalText() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatUint(uint64(nu.Uint16), 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (nu *NullUint16) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *uint16
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		nu.Uint16, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	nu.Uint16, nu.Valid, nu.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (nu NullUint16) MarshalYAML() (any, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return nu.Uint16, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (nu *NullUint16) Scan(src any) error {
+	v, valid, err := scan[uint16](src)
+	if err != nil {
+		return err
+	}
+	nu.Uint16, nu.Valid, nu.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nu NullUint16) Value() (driver.Value, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return int64(nu.Uint16), nil
+}
+
+// NullUint32 represents a uint32 that may be null or may be absent.
+// NullUint32 implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for uint32 or that are not integers are rejected.
+type NullUint32 struct {
+	Uint32  uint32
+	Valid   bool // Valid is true if Uint32 is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (nu *NullUint32) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		nu.Uint32, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, nu) {
+		return nil
+	}
+	n, err := unmarshalUint(data, &nu.Uint32, 32)
+	if err != nil {
+		return err
+	}
+	nu.Uint32, nu.Valid, nu.Present = uint32(n), true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (nu NullUint32) MarshalJSON() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nu.Uint32)
+}
+
+// IsZero reports whether nu is absent, so that the omitzero tag option omits absent fields.
+func (nu NullUint32) IsZero() bool {
+	return !nu.Present
+}
+
+// Get returns the value of nu and true if nu is set, or the zero value and false otherwise.
+func (nu NullUint32) Get() (uint32, bool) {
+	if nu.State() != StateSet {
+		return 0, false
+	}
+	return nu.Uint32, true
+}
+
+// State returns the state of nu.
+func (nu NullUint32) State() State {
+	return stateOf(nu.Valid, nu.Present)
+}
+
+// Set sets nu to v.
+func (nu *NullUint32) Set(v uint32) {
+	nu.Uint32, nu.Valid, nu.Present = v, true, true
+}
+
+// SetNull sets nu to null.
+func (nu *NullUint32) SetNull() {
+	nu.Uint32, nu.Valid, nu.Present = 0, false, true
+}
+
+// Unset makes nu absent.
+func (nu *NullUint32) Unset() {
+	*nu = NullUint32{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (nu *NullUint32) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		nu.Uint32, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 32)
+	if err != nil {
+		return err
+	}
+	nu.Uint32, nu.Valid, nu.Present = uint32(n), true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (nu NullUint32) MarshalText() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatUint(uint64(nu.Uint32), 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (nu *NullUint32) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *uint32
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		nu.Uint32, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	nu.Uint32, nu.Valid, nu.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (nu NullUint32) MarshalYAML() (any, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return nu.Uint32, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (nu *NullUint32) Scan(src any) error {
+	v, valid, err := scan[uint32](src)
+	if err != nil {
+		return err
+	}
+	nu.Uint32, nu.Valid, nu.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nu NullUint32) Value() (driver.Value, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return int64(nu.Uint32), nil
+}
+
+// NullUint64 represents a uint64 that may be null or may be absent.
+// NullUint64 implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
+// Numbers that are out of range for uint64 or that are not integers are rejected.
+type NullUint64 struct {
+	Uint64  uint64
+	Valid   bool // Valid is true if Uint64 is not NULL
+	Present bool // Present is true if the field is present during Unmarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (nu *NullUint64) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		nu.Uint64, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	if unmarshalQuoted(data, nu) {
+		return nil
+	}
+	n, err := unmarshalUint(data, &nu.Uint64, 64)
+	if err != nil {
+		return err
+	}
+	nu.Uint64, nu.Valid, nu.Present = n, true, true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (nu NullUint64) MarshalJSON() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nu.Uint64)
+}
+
+// IsZero reports whether nu is absent, so that the omitzero tag option omits absent fields.
+func (nu NullUint64) IsZero() bool {
+	return !nu.Present
+}
+
+// Get returns the value of nu and true if nu is set, or the zero value and false otherwise.
+func (nu NullUint64) Get() (uint64, bool) {
+	if nu.State() != StateSet {
+		return 0, false
+	}
+	return nu.Uint64, true
+}
+
+// State returns the state of nu.
+func (nu NullUint64) State() State {
+	return stateOf(nu.Valid, nu.Present)
+}
+
+// Set sets nu to v.
+func (nu *NullUint64) Set(v uint64) {
+	nu.Uint64, nu.Valid, nu.Present = v, true, true
+}
+
+// SetNull sets nu to null.
+func (nu *NullUint64) SetNull() {
+	nu.Uint64, nu.Valid, nu.Present = 0, false, true
+}
+
+// Unset makes nu absent.
+func (nu *NullUint64) Unset() {
+	*nu = NullUint64{}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (nu *NullUint64) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		nu.Uint64, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 64)
+	if err != nil {
+		return err
+	}
+	nu.Uint64, nu.Valid, nu.Present = n, true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (nu NullUint64) MarshalText() ([]byte, error) {
+	if !nu.Present || !nu.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatUint(nu.Uint64, 10)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (nu *NullUint64) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *uint64
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		nu.Uint64, nu.Valid, nu.Present = 0, false, true
+		return nil
+	}
+	nu.Uint64, nu.Valid, nu.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (nu NullUint64) MarshalYAML() (any, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return nu.Uint64, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (nu *NullUint64) Scan(src any) error {
+	v, valid, err := scan[uint64](src)
+	if err != nil {
+		return err
+	}
+	nu.Uint64, nu.Valid, nu.Present = v, valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nu NullUint64) Value() (driver.Value, error) {
+	if !nu.Present || !nu.Valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(nu.Uint64)
+}
@@ -0,0 +1,175 @@
+package jsontype
+
+import (
+	"testing"
+)
+
+// Test the UnmarshalJSON method of NullInt8
+func TestNullInt8_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       []byte
+		expected    NullInt8
+		expectedErr string
+	}{
+		{
+			name:     "Valid int8",
+			input:    []byte(`-128`),
+			expected: NullInt8{Int8: -128, Valid: true, Present: true},
+		},
+		{
+			name:     "Null value",
+			input:    []byte(`null`),
+			expected: NullInt8{Int8: 0, Valid: false, Present: true},
+		},
+		{
+			name:        "Above maximum",
+			input:       []byte(`128`),
+			expectedErr: "jsontype: cannot unmarshal 128: expected integer or null: out of range for int8, maximum is 127",
+		},
+		{
+			name:        "Below minimum",
+			input:       []byte(`-129`),
+			expectedErr: "jsontype: cannot unmarshal -129: expected integer or null: out of range for int8, minimum is -128",
+		},
+		{
+			name:        "Fractional number",
+			input:       []byte(`1.5`),
+			expectedErr: "jsontype: cannot unmarshal 1.5: expected integer or null: not an integer",
+		},
+		{
+			name:        "Exponent",
+			input:       []byte(`1e2`),
+			expectedErr: "jsontype: cannot unmarshal 1e2: expected integer or null: not an integer",
+		},
+		{
+			name:        "Invalid number",
+			input:       []byte(`01`),
+			expectedErr: "jsontype: cannot unmarshal 01: expected integer or null: invalid character '1' after top-level value",
+		},
+		{
+			name:        "Invalid type (string)",
+			input:       []byte(`"x"`),
+			expectedErr: `jsontype: cannot unmarshal "x": expected integer or null: json: cannot unmarshal string into Go value of type int8`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ni NullInt8
+			err := ni.UnmarshalJSON(tt.input)
+			if (err != nil || tt.expectedErr != "") && (err == nil || err.Error() != tt.expectedErr) {
+				t.Errorf("UnmarshalJSON() error = %v, expected %q", err, tt.expectedErr)
+				return
+			}
+			if ni != tt.expected {
+				t.Errorf("UnmarshalJSON() = %v, expected %v", ni, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the error messages of UnmarshalJSON for out of range numbers
+func TestFixedWidth_UnmarshalJSONError(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst      interface{ UnmarshalJSON([]byte) error }
+		input    string
+		expected string
+	}{
+		{name: "NullInt16", dst: &NullInt16{}, input: `32768`, expected: "jsontype: cannot unmarshal 32768: expected integer or null: out of range for int16, maximum is 32767"},
+		{name: "NullInt32", dst: &NullInt32{}, input: `-2147483649`, expected: "jsontype: cannot unmarshal -2147483649: expected integer or null: out of range for int32, minimum is -2147483648"},
+		{name: "NullInt64", dst: &NullInt64{}, input: `9223372036854775808`, expected: "jsontype: cannot unmarshal 9223372036854775808: expected integer or null: out of range for int64, maximum is 9223372036854775807"},
+		{name: "NullUint", dst: &NullUint{}, input: `-1`, expected: "jsontype: cannot unmarshal -1: expected integer or null: out of range for uint, minimum is 0"},
+		{name: "NullUint8", dst: &NullUint8{}, input: `256`, expected: "jsontype: cannot unmarshal 256: expected integer or null: out of range for uint8, maximum is 255"},
+		{name: "NullUint16", dst: &NullUint16{}, input: `0.5`, expected: "jsontype: cannot unmarshal 0.5: expected integer or null: not an integer"},
+		{name: "NullUint32", dst: &NullUint32{}, input: `4294967296`, expected: "jsontype: cannot unmarshal 4294967296: expected integer or null: out of range for uint32, maximum is 4294967295"},
+		{name: "NullUint64", dst: &NullUint64{}, input: `18446744073709551616`, expected: "jsontype: cannot unmarshal 18446744073709551616: expected integer or null: out of range for uint64, maximum is 18446744073709551615"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dst.UnmarshalJSON([]byte(tt.input))
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("UnmarshalJSON() error = %v, expected %s", err, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the UnmarshalJSON method of NullUint64
+func TestNullUint64_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []byte
+		expected  NullUint64
+		expectErr bool
+	}{
+		{
+			name:     "Maximum",
+			input:    []byte(`18446744073709551615`),
+			expected: NullUint64{Uint64: 18446744073709551615, Valid: true, Present: true},
+		},
+		{
+			name:     "Null value",
+			input:    []byte(`null`),
+			expected: NullUint64{Present: true},
+		},
+		{
+			name:      "Missing field",
+			input:     nil,
+			expectErr: true,
+		},
+		{
+			name:      "Invalid type (object)",
+			input:     []byte(`{}`),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var nu NullUint64
+			err := nu.UnmarshalJSON(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("UnmarshalJSON() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if nu != tt.expected {
+				t.Errorf("UnmarshalJSON() = %v, expected %v", nu, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the MarshalJSON method of the fixed-width integer types
+func TestFixedWidth_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{ MarshalJSON() ([]byte, error) }
+		expected string
+	}{
+		{name: "NullInt8", input: NullInt8{Int8: -128, Valid: true, Present: true}, expected: `-128`},
+		{name: "NullInt16 null", input: NullInt16{Int16: 1, Present: true}, expected: `null`},
+		{name: "NullInt32", input: NullInt32{Int32: 123, Valid: true, Present: true}, expected: `123`},
+		{name: "NullInt64 absent", input: NullInt64{Int64: 1, Valid: true}, expected: `null`},
+		{name: "NullUint", input: NullUint{Uint: 123, Valid: true, Present: true}, expected: `123`},
+		{name: "NullUint8", input: NullUint8{Uint8: 255, Valid: true, Present: true}, expected: `255`},
+		{name: "NullUint16", input: NullUint16{Uint16: 0, Valid: true, Present: true}, expected: `0`},
+		{name: "NullUint32", input: NullUint32{Uint32: 4294967295, Valid: true, Present: true}, expected: `4294967295`},
+		{name: "NullUint64", input: NullUint64{Uint64: 18446744073709551615, Valid: true, Present: true}, expected: `18446744073709551615`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.input.MarshalJSON()
+			if err != nil {
+				t.Errorf("MarshalJSON() error = %v", err)
+				return
+			}
+			if string(result) != tt.expected {
+				t.Errorf("MarshalJSON() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
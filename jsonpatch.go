@@ -0,0 +1,104 @@
+package jsontype
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// Operation is a JSON Patch (RFC 6902) operation.
+type Operation struct {
+	Op    string // Op is the operation, "replace" or "remove"
+	Path  string // Path is the JSON Pointer (RFC 6901) to the target location
+	Value any    // Value is the new value for replace operations
+}
+
+// MarshalJSON implements the json.Marshaler interface. The value member is omitted for remove
+// operations and written, also if it is nil, for all other operations.
+func (op Operation) MarshalJSON() ([]byte, error) {
+	if op.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{op.Op, op.Path})
+	}
+	return json.Marshal(struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}{op.Op, op.Path, op.Value})
+}
+
+// PatchOption configures ToJSONPatch.
+type PatchOption func(*patchOptions)
+
+type patchOptions struct {
+	replaceNull bool
+}
+
+// ReplaceNull makes ToJSONPatch emit a replace operation with a null value for fields that are
+// present and null, instead of a remove operation.
+func ReplaceNull() PatchOption {
+	return func(o *patchOptions) { o.replaceNull = true }
+}
+
+// ToJSONPatch returns the JSON Patch (RFC 6902) operations described by v, a struct or a pointer
+// to a struct containing the Null types of this package.
+//
+// Fields that are present and valid produce a replace operation with their value, fields that
+// are present and null produce a remove operation and absent fields produce no operation. Paths
+// are derived from the json tags of the fields. Struct fields that are not Null types are walked
+// recursively; other fields are ignored. Operations are returned in field order.
+func ToJSONPatch(v any, opts ...PatchOption) ([]Operation, error) {
+	var o patchOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("jsontype: ToJSONPatch requires a struct or a pointer to a struct, got %T", v)
+	}
+	return appendOperations(nil, rv, "", &o), nil
+}
+
+func appendOperations(ops []Operation, v reflect.Value, path string, o *patchOptions) []Operation {
+	for _, f := range typeinfo.Fields(v.Type(), "json") {
+		fv, _ := typeinfo.FieldByIndex(v, f.Index)
+		fpath := appendPointer(path, f.Name)
+		if value, valid, present, ok := typeinfo.NullParts(fv); ok {
+			switch {
+			case !present.Bool():
+			case valid.Bool():
+				ops = append(ops, Operation{Op: "replace", Path: fpath, Value: value.Interface()})
+			case o.replaceNull:
+				ops = append(ops, Operation{Op: "replace", Path: fpath})
+			default:
+				ops = append(ops, Operation{Op: "remove", Path: fpath})
+			}
+			continue
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct {
+			ops = appendOperations(ops, fv, fpath, o)
+		}
+	}
+	return ops
+}
+
+// appendPointer appends name as a reference token to the JSON Pointer (RFC 6901) path.
+func appendPointer(path, name string) string {
+	name = strings.ReplaceAll(name, "~", "~0")
+	name = strings.ReplaceAll(name, "/", "~1")
+	return path + "/" + name
+}
@@ -0,0 +1,126 @@
+package jsontype
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type jsonPatchAddress struct {
+	City NullString `json:"city"`
+	Zip  NullString `json:"zip/code"`
+}
+
+type jsonPatchPerson struct {
+	Name    NullString        `json:"name"`
+	Age     NullInt           `json:"age"`
+	Tags    Null[[]string]    `json:"tags"`
+	Address jsonPatchAddress  `json:"address"`
+	Manager *jsonPatchAddress `json:"manager"`
+	Ignored string            `json:"ignored"`
+}
+
+// Test the ToJSONPatch function
+func TestToJSONPatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     any
+		opts      []PatchOption
+		expected  []Operation
+		expectErr bool
+	}{
+		{
+			name:     "Absent fields",
+			input:    jsonPatchPerson{Ignored: "x"},
+			expected: nil,
+		},
+		{
+			name: "Valid and null fields",
+			input: &jsonPatchPerson{
+				Name: NullString{String: "John", Valid: true, Present: true},
+				Age:  NullInt{Present: true},
+				Tags: Null[[]string]{Value: []string{"a"}, Valid: true, Present: true},
+			},
+			expected: []Operation{
+				{Op: "replace", Path: "/name", Value: "John"},
+				{Op: "remove", Path: "/age"},
+				{Op: "replace", Path: "/tags", Value: []string{"a"}},
+			},
+		},
+		{
+			name:  "Null as replace",
+			input: jsonPatchPerson{Age: NullInt{Present: true}},
+			opts:  []PatchOption{ReplaceNull()},
+			expected: []Operation{
+				{Op: "replace", Path: "/age"},
+			},
+		},
+		{
+			name: "Nested structs",
+			input: jsonPatchPerson{
+				Address: jsonPatchAddress{Zip: NullString{String: "1234AB", Valid: true, Present: true}},
+				Manager: &jsonPatchAddress{City: NullString{Present: true}},
+			},
+			expected: []Operation{
+				{Op: "replace", Path: "/address/zip~1code", Value: "1234AB"},
+				{Op: "remove", Path: "/manager/city"},
+			},
+		},
+		{
+			name:      "Invalid input",
+			input:     []string{"a"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToJSONPatch(tt.input, tt.opts...)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ToJSONPatch() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ToJSONPatch() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the MarshalJSON method of Operation
+func TestOperation_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Operation
+		expected string
+	}{
+		{
+			name:     "Replace",
+			input:    Operation{Op: "replace", Path: "/name", Value: "John"},
+			expected: `{"op":"replace","path":"/name","value":"John"}`,
+		},
+		{
+			name:     "Replace with null",
+			input:    Operation{Op: "replace", Path: "/name"},
+			expected: `{"op":"replace","path":"/name","value":null}`,
+		},
+		{
+			name:     "Remove",
+			input:    Operation{Op: "remove", Path: "/name"},
+			expected: `{"op":"remove","path":"/name"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := json.Marshal(tt.input)
+			if err != nil {
+				t.Errorf("MarshalJSON() error = %v", err)
+				return
+			}
+			if string(result) != tt.expected {
+				t.Errorf("MarshalJSON() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
@@ -5,8 +5,18 @@
 // integrate with Go's standard JSON handling. Each type includes a Valid field, which indicates whether the value
 // is not null, and a Present field, which indicates whether the field was included in the JSON input.
 //
-// Note: When marshaling, these types do not fully support the 'omitempty' tag. If a field's Present field is false,
-// the field will still be included in the output JSON with a null value.
+// The types also implement the sql.Scanner and driver.Valuer interfaces, so they can be read from and written
+// to a database, with SQL NULL mapping to a present null value. The generic Null type cannot implement
+// driver.Valuer because of its Value field; use its Valuer method to pass it as a query argument.
+//
+// The types implement the encoding.TextMarshaler and encoding.TextUnmarshaler interfaces as well, with the
+// text NullText representing null, so they can be used for query parameters, headers and map keys. As encoding/json
+// decodes map keys with UnmarshalJSON, the boolean and number types also accept their text form as a JSON string.
+// They also implement the Unmarshaler and Marshaler interfaces of gopkg.in/yaml.v2 without importing it.
+//
+// Note: When marshaling, these types do not support the 'omitempty' tag. If a field's Present field is false, the
+// field will still be included in the output JSON with a null value. To omit absent fields, use the 'omitzero' tag
+// (Go 1.24 and later), which uses the IsZero method of the types, or marshal with the Marshal function of this package.
 //
 // The jsontype package is designed to work alongside the standard library's encoding/json package and should be
 // compatible with third-party JSON encoding packages, although this has not been extensively tested.
@@ -15,6 +25,7 @@ package jsontype
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 	"time"
 )
 
@@ -34,8 +45,15 @@ func (nb *NullBool) UnmarshalJSON(data []byte) error {
 		nb.Bool, nb.Valid, nb.Present = false, false, true
 		return nil
 	}
+	if v, ok := parseBool(data); ok {
+		nb.Bool, nb.Valid, nb.Present = v, true, true
+		return nil
+	}
+	if unmarshalQuoted(data, nb) {
+		return nil
+	}
 	if err := json.Unmarshal(data, &nb.Bool); err != nil {
-		return err
+		return newDecodeError(data, "boolean", err)
 	}
 	nb.Valid, nb.Present = true, true
 	return nil
@@ -49,6 +67,11 @@ func (nb NullBool) MarshalJSON() ([]byte, error) {
 	return json.Marshal(nb.Bool)
 }
 
+// IsZero reports whether nb is absent, so that the omitzero tag option omits absent fields.
+func (nb NullBool) IsZero() bool {
+	return !nb.Present
+}
+
 // NullFloat64 represents a float64 that may be null or may be absent.
 // NullFloat64 implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
 type NullFloat64 struct {
@@ -63,8 +86,15 @@ func (nf *NullFloat64) UnmarshalJSON(data []byte) error {
 		nf.Float64, nf.Valid, nf.Present = 0, false, true
 		return nil
 	}
+	if v, ok := parseFloat(data); ok {
+		nf.Float64, nf.Valid, nf.Present = v, true, true
+		return nil
+	}
+	if unmarshalQuoted(data, nf) {
+		return nil
+	}
 	if err := json.Unmarshal(data, &nf.Float64); err != nil {
-		return err
+		return newDecodeError(data, "number", err)
 	}
 	nf.Valid, nf.Present = true, true
 	return nil
@@ -78,6 +108,11 @@ func (nf NullFloat64) MarshalJSON() ([]byte, error) {
 	return json.Marshal(nf.Float64)
 }
 
+// IsZero reports whether nf is absent, so that the omitzero tag option omits absent fields.
+func (nf NullFloat64) IsZero() bool {
+	return !nf.Present
+}
+
 // NullInt represents an int that may be null or may be absent.
 // NullInt implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
 type NullInt struct {
@@ -92,8 +127,15 @@ func (ni *NullInt) UnmarshalJSON(data []byte) error {
 		ni.Int, ni.Valid, ni.Present = 0, false, true
 		return nil
 	}
+	if v, ok := parseInt(data); ok {
+		ni.Int, ni.Valid, ni.Present = v, true, true
+		return nil
+	}
+	if unmarshalQuoted(data, ni) {
+		return nil
+	}
 	if err := json.Unmarshal(data, &ni.Int); err != nil {
-		return err
+		return newDecodeError(data, "integer", err)
 	}
 	ni.Valid, ni.Present = true, true
 	return nil
@@ -107,6 +149,11 @@ func (ni NullInt) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ni.Int)
 }
 
+// IsZero reports whether ni is absent, so that the omitzero tag option omits absent fields.
+func (ni NullInt) IsZero() bool {
+	return !ni.Present
+}
+
 // NullString represents a string that may be null or may be absent.
 // NullString implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
 type NullString struct {
@@ -121,8 +168,12 @@ func (ns *NullString) UnmarshalJSON(data []byte) error {
 		ns.String, ns.Valid, ns.Present = "", false, true
 		return nil
 	}
+	if v, ok := parseString(data); ok {
+		ns.String, ns.Valid, ns.Present = v, true, true
+		return nil
+	}
 	if err := json.Unmarshal(data, &ns.String); err != nil {
-		return err
+		return newDecodeError(data, "string", err)
 	}
 	ns.Valid, ns.Present = true, true
 	return nil
@@ -136,6 +187,11 @@ func (ns NullString) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ns.String)
 }
 
+// IsZero reports whether ns is absent, so that the omitzero tag option omits absent fields.
+func (ns NullString) IsZero() bool {
+	return !ns.Present
+}
+
 // NullTime represents a time.Time that may be null or may be absent.
 // NullTime implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
 type NullTime struct {
@@ -150,8 +206,12 @@ func (nt *NullTime) UnmarshalJSON(data []byte) error {
 		nt.Time, nt.Valid, nt.Present = time.Time{}, false, true
 		return nil
 	}
+	if v, ok := parseTime(data); ok {
+		nt.Time, nt.Valid, nt.Present = v, true, true
+		return nil
+	}
 	if err := json.Unmarshal(data, &nt.Time); err != nil {
-		return err
+		return newDecodeError(data, "string", err)
 	}
 	nt.Valid, nt.Present = true, true
 	return nil
@@ -165,6 +225,11 @@ func (nt NullTime) MarshalJSON() ([]byte, error) {
 	return json.Marshal(nt.Time)
 }
 
+// IsZero reports whether nt is absent, so that the omitzero tag option omits absent fields.
+func (nt NullTime) IsZero() bool {
+	return !nt.Present
+}
+
 // Null represents a generic value that may be null or may be absent.
 // Null implements the json.Unmarshaler and can be used as a json.Unmarshal destination.
 type Null[T any] struct {
@@ -180,7 +245,7 @@ func (nt *Null[T]) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 	if err := json.Unmarshal(data, &nt.Value); err != nil {
-		return err
+		return newDecodeError(data, jsonKind(reflect.TypeOf(&nt.Value).Elem()), err)
 	}
 	nt.Valid, nt.Present = true, true
 	return nil
@@ -193,3 +258,8 @@ func (nt Null[T]) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(nt.Value)
 }
+
+// IsZero reports whether nt is absent, so that the omitzero tag option omits absent fields.
+func (nt Null[T]) IsZero() bool {
+	return !nt.Present
+}
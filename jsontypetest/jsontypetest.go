@@ -0,0 +1,193 @@
+// Package jsontypetest provides helpers for testing code that uses the Null types of package
+// jsontype: assertions on the state and value of a Null value, random generators for
+// testing/quick that produce absent, null and set values, and a check that a value survives a JSON
+// round trip.
+package jsontypetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mbe81/jsontype"
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// AssertAbsent reports an error on t if n, a value of one of the Null types, is not absent. It
+// returns whether the assertion holds.
+func AssertAbsent(t testing.TB, n any) bool {
+	t.Helper()
+	return assertState(t, n, jsontype.StateAbsent, nil)
+}
+
+// AssertNull reports an error on t if n, a value of one of the Null types, is not a present null.
+// It returns whether the assertion holds.
+func AssertNull(t testing.TB, n any) bool {
+	t.Helper()
+	return assertState(t, n, jsontype.StateNull, nil)
+}
+
+// AssertValue reports an error on t if n, a value of one of the Null types, is not set to expected.
+// Values are compared with their Equal method if they have one, such as time.Time, and with
+// reflect.DeepEqual otherwise. It returns whether the assertion holds.
+func AssertValue[T any](t testing.TB, n any, expected T) bool {
+	t.Helper()
+	return assertState(t, n, jsontype.StateSet, expected)
+}
+
+func assertState(t testing.TB, n any, state jsontype.State, expected any) bool {
+	t.Helper()
+	v := reflect.ValueOf(n)
+	if !v.IsValid() || !typeinfo.IsNull(v.Type()) {
+		t.Errorf("jsontypetest: %T is not a Null type", n)
+		return false
+	}
+	if got := stateOf(v); got != state || (state == jsontype.StateSet && !equal(v.Field(0).Interface(), expected)) {
+		t.Errorf("%T: got %s, expected %s", n, describe(v), describeState(state, expected))
+		return false
+	}
+	return true
+}
+
+// RoundTrip checks that v, typically a struct containing Null fields, is unchanged when it is
+// marshaled with jsontype.Marshal, which omits absent values, and unmarshaled with json.Unmarshal
+// into a new value of the same type. It reports every field that differs as an error on t and
+// returns whether the round trip is symmetric.
+//
+// Absent values can only be restored as struct fields, so a Null value passed to RoundTrip
+// directly must not be absent.
+func RoundTrip(t testing.TB, v any) bool {
+	t.Helper()
+	data, err := jsontype.Marshal(v)
+	if err != nil {
+		t.Errorf("jsontypetest: marshal %T: %v", v, err)
+		return false
+	}
+	got := reflect.New(reflect.TypeOf(v))
+	if err := json.Unmarshal(data, got.Interface()); err != nil {
+		t.Errorf("jsontypetest: unmarshal %s into %T: %v", data, v, err)
+		return false
+	}
+	diffs := diff(nil, got.Elem(), reflect.ValueOf(v), "")
+	for _, d := range diffs {
+		t.Errorf("%T round trip through %s: %s", v, data, d)
+	}
+	return len(diffs) == 0
+}
+
+// diff appends a description of every difference between got and expected, identified by the JSON
+// Pointer of the value, to diffs.
+func diff(diffs []string, got, expected reflect.Value, path string) []string {
+	if typeinfo.IsNull(got.Type()) {
+		if stateOf(got) != stateOf(expected) {
+			return append(diffs, fmt.Sprintf("%s: got %s, expected %s", pathOrRoot(path), describe(got), describe(expected)))
+		}
+		if stateOf(expected) == jsontype.StateSet {
+			return diff(diffs, got.Field(0), expected.Field(0), path)
+		}
+		return diffs
+	}
+	switch got.Kind() {
+	case reflect.Struct:
+		if !hasEqual(got.Type()) {
+			for _, f := range typeinfo.Fields(got.Type(), "json") {
+				gf, _ := typeinfo.FieldByIndex(got, f.Index)
+				ef, _ := typeinfo.FieldByIndex(expected, f.Index)
+				diffs = diff(diffs, gf, ef, path+"/"+escaper.Replace(f.Name))
+			}
+			return diffs
+		}
+	case reflect.Pointer:
+		if !got.IsNil() && !expected.IsNil() {
+			return diff(diffs, got.Elem(), expected.Elem(), path)
+		}
+	case reflect.Slice, reflect.Array:
+		if got.Len() == expected.Len() && got.IsNil() == expected.IsNil() {
+			for i := 0; i < got.Len(); i++ {
+				diffs = diff(diffs, got.Index(i), expected.Index(i), path+"/"+strconv.Itoa(i))
+			}
+			return diffs
+		}
+	case reflect.Map:
+		if got.Len() == expected.Len() && got.IsNil() == expected.IsNil() && got.Type().Key().Kind() == reflect.String {
+			iter := expected.MapRange()
+			for iter.Next() {
+				elem := got.MapIndex(iter.Key())
+				if !elem.IsValid() {
+					return append(diffs, fmt.Sprintf("%s: got %s, expected %s", pathOrRoot(path), format(got.Interface()), format(expected.Interface())))
+				}
+				diffs = diff(diffs, elem, iter.Value(), path+"/"+escaper.Replace(iter.Key().String()))
+			}
+			return diffs
+		}
+	}
+	if !equal(got.Interface(), expected.Interface()) {
+		diffs = append(diffs, fmt.Sprintf("%s: got %s, expected %s", pathOrRoot(path), format(got.Interface()), format(expected.Interface())))
+	}
+	return diffs
+}
+
+// stateOf returns the state of the Null value v.
+func stateOf(v reflect.Value) jsontype.State {
+	_, valid, present, _ := typeinfo.NullParts(v)
+	switch {
+	case !present.Bool():
+		return jsontype.StateAbsent
+	case !valid.Bool():
+		return jsontype.StateNull
+	}
+	return jsontype.StateSet
+}
+
+// describe returns the state of the Null value v, followed by its value if it is set.
+func describe(v reflect.Value) string {
+	return describeState(stateOf(v), v.Field(0).Interface())
+}
+
+func describeState(state jsontype.State, value any) string {
+	if state != jsontype.StateSet {
+		return state.String()
+	}
+	return "set " + format(value)
+}
+
+// format formats v for an error message, quoting strings and times.
+func format(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case time.Time:
+		return strconv.Quote(v.Format(time.RFC3339Nano))
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// equal reports whether a and b are equal according to the Equal method of a, or
+// reflect.DeepEqual if a has no such method.
+func equal(a, b any) bool {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.IsValid() && bv.IsValid() && av.Type() == bv.Type() && hasEqual(av.Type()) {
+		return av.MethodByName("Equal").Call([]reflect.Value{bv})[0].Bool()
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// hasEqual reports whether t has a method Equal(t) bool.
+func hasEqual(t reflect.Type) bool {
+	m, ok := t.MethodByName("Equal")
+	return ok && m.Type.NumIn() == 2 && m.Type.In(1) == t && m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Bool
+}
+
+// escaper escapes a reference token of a JSON Pointer (RFC 6901).
+var escaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return path
+}
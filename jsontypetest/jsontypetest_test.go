@@ -0,0 +1,154 @@
+package jsontypetest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/mbe81/jsontype"
+)
+
+// recorder is a testing.TB that records the reported errors.
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+type address struct {
+	Street jsontype.NullString `json:"street"`
+	Zip    jsontype.NullUint16 `json:"zip"`
+}
+
+type person struct {
+	Name     jsontype.Null[string]   `json:"name"`
+	Age      jsontype.NullInt8       `json:"age"`
+	Score    jsontype.NullFloat64    `json:"score"`
+	Birthday jsontype.NullTime       `json:"birthday"`
+	Address  jsontype.Null[address]  `json:"address"`
+	Phones   []string                `json:"phones"`
+	Tags     jsontype.Null[[]string] `json:"tags"`
+	Labels   map[string]int          `json:"labels"`
+}
+
+type contacts struct {
+	Phones []jsontype.NullString          `json:"phones"`
+	Emails map[string]jsontype.NullString `json:"emails"`
+}
+
+// Test the AssertAbsent, AssertNull and AssertValue functions
+func TestAssert(t *testing.T) {
+	birthday := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		assert   func(t testing.TB) bool
+		expected string
+	}{
+		{name: "Absent", assert: func(t testing.TB) bool { return AssertAbsent(t, jsontype.NullInt{}) }},
+		{name: "Null", assert: func(t testing.TB) bool { return AssertNull(t, jsontype.NullValue[int]()) }},
+		{name: "Value", assert: func(t testing.TB) bool { return AssertValue(t, jsontype.Value("John"), "John") }},
+		{
+			name: "Value with Equal method",
+			assert: func(t testing.TB) bool {
+				return AssertValue(t, jsontype.NullTime{Time: birthday.Local(), Valid: true, Present: true}, birthday)
+			},
+		},
+		{
+			name:     "Absent is null",
+			assert:   func(t testing.TB) bool { return AssertAbsent(t, jsontype.NullString{Present: true}) },
+			expected: "jsontype.NullString: got null, expected absent",
+		},
+		{
+			name:     "Null is set",
+			assert:   func(t testing.TB) bool { return AssertNull(t, jsontype.Value(42)) },
+			expected: "jsontype.Null[int]: got set 42, expected null",
+		},
+		{
+			name:     "Value differs",
+			assert:   func(t testing.TB) bool { return AssertValue(t, jsontype.Value("Jon"), "John") },
+			expected: `jsontype.Null[string]: got set "Jon", expected set "John"`,
+		},
+		{
+			name:     "Value is absent",
+			assert:   func(t testing.TB) bool { return AssertValue(t, jsontype.NullInt{}, 7) },
+			expected: "jsontype.NullInt: got absent, expected set 7",
+		},
+		{
+			name:     "Not a Null type",
+			assert:   func(t testing.TB) bool { return AssertAbsent(t, 42) },
+			expected: "jsontypetest: int is not a Null type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			ok := tt.assert(r)
+			var got string
+			if len(r.errs) > 0 {
+				got = r.errs[0]
+			}
+			if ok != (tt.expected == "") || len(r.errs) > 1 || got != tt.expected {
+				t.Errorf("assertion = %t with errors %q, expected %q", ok, r.errs, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the RoundTrip function
+func TestRoundTrip(t *testing.T) {
+	p := person{
+		Name:    jsontype.Value("John"),
+		Age:     jsontype.NullInt8{Present: true},
+		Address: jsontype.Value(address{Zip: jsontype.NullUint16{Uint16: 1234, Valid: true, Present: true}}),
+		Phones:  []string{"555"},
+		Labels:  map[string]int{"a": 1},
+	}
+	if !RoundTrip(t, p) {
+		t.Errorf("RoundTrip() = false, expected true")
+	}
+
+	r := &recorder{TB: t}
+	if RoundTrip(r, contacts{Phones: []jsontype.NullString{{Present: true}, {}}, Emails: map[string]jsontype.NullString{"a/b": {}}}) {
+		t.Errorf("RoundTrip() = true, expected false for an absent slice element")
+	}
+	expected := []string{
+		`jsontypetest.contacts round trip through {"phones":[null,null],"emails":{"a/b":null}}: /phones/1: got null, expected absent`,
+		`jsontypetest.contacts round trip through {"phones":[null,null],"emails":{"a/b":null}}: /emails/a~1b: got null, expected absent`,
+	}
+	if !reflect.DeepEqual(r.errs, expected) {
+		t.Errorf("RoundTrip() errors = %q, expected %q", r.errs, expected)
+	}
+}
+
+// Test that Value generates all states of the Null types
+func TestValue(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	states := make(map[jsontype.State]int)
+	for i := 0; i < 300; i++ {
+		states[Generate[jsontype.Null[int]](r).State()]++
+	}
+	if len(states) != 3 || states[jsontype.StateAbsent] < 50 || states[jsontype.StateNull] < 50 || states[jsontype.StateSet] < 50 {
+		t.Errorf("Generate() states = %v, expected all states", states)
+	}
+
+	if _, ok := Value(reflect.TypeOf(jsontype.Null[chan int]{}), r); ok {
+		t.Errorf("Value() ok = true, expected false for a channel")
+	}
+}
+
+// Test that generated values survive a round trip with quick.Check
+func TestValues(t *testing.T) {
+	f := func(p person) bool { return RoundTrip(t, p) }
+	if err := quick.Check(f, &quick.Config{Values: Values(f), Rand: rand.New(rand.NewSource(1))}); err != nil {
+		t.Error(err)
+	}
+}
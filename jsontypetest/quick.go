@@ -0,0 +1,133 @@
+package jsontypetest
+
+import (
+	"math/rand"
+	"reflect"
+	"testing/quick"
+	"time"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// maxLength is the maximum length of the slices and maps generated by Value.
+const maxLength = 10
+
+// Value returns a random value of type t, like quick.Value, but also for types containing the Null
+// types of package jsontype and time.Time. Null values are absent, null or set with equal
+// probability, and set values hold a random value of their value type. Times are random instants
+// in UTC between 1970 and 2242 with nanosecond precision, and unexported struct fields are left at
+// their zero value. Note that absent Null values only survive a JSON round trip as struct fields,
+// not as elements of slices or maps.
+//
+// Value returns false if it cannot generate a value of type t, such as a channel or a function.
+func Value(t reflect.Type, r *rand.Rand) (reflect.Value, bool) {
+	v := reflect.New(t).Elem()
+	if value, valid, present, ok := typeinfo.NullParts(v); ok {
+		elem, ok := Value(value.Type(), r)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		switch r.Intn(3) {
+		case 1:
+			present.SetBool(true)
+		case 2:
+			value.Set(elem)
+			valid.SetBool(true)
+			present.SetBool(true)
+		}
+		return v, true
+	}
+	if t == timeType {
+		v.Set(reflect.ValueOf(time.Unix(r.Int63n(1<<33), r.Int63n(int64(time.Second))).UTC()))
+		return v, true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			f, ok := Value(t.Field(i).Type, r)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Field(i).Set(f)
+		}
+	case reflect.Pointer:
+		if r.Intn(maxLength) == 0 {
+			return v, true
+		}
+		elem, ok := Value(t.Elem(), r)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		v.Set(reflect.New(t.Elem()))
+		v.Elem().Set(elem)
+	case reflect.Slice:
+		n := r.Intn(maxLength)
+		v.Set(reflect.MakeSlice(t, n, n))
+		for i := 0; i < n; i++ {
+			elem, ok := Value(t.Elem(), r)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Index(i).Set(elem)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elem, ok := Value(t.Elem(), r)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.Index(i).Set(elem)
+		}
+	case reflect.Map:
+		n := r.Intn(maxLength)
+		v.Set(reflect.MakeMapWithSize(t, n))
+		for i := 0; i < n; i++ {
+			key, ok := Value(t.Key(), r)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			elem, ok := Value(t.Elem(), r)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			v.SetMapIndex(key, elem)
+		}
+	default:
+		return quick.Value(t, r)
+	}
+	return v, true
+}
+
+// Generate returns a random value of type T, as generated by Value. It panics if Value cannot
+// generate a value of type T.
+func Generate[T any](r *rand.Rand) T {
+	v, ok := Value(reflect.TypeOf((*T)(nil)).Elem(), r)
+	if !ok {
+		panic("jsontypetest: cannot generate a value of type " + reflect.TypeOf((*T)(nil)).Elem().String())
+	}
+	return v.Interface().(T)
+}
+
+// Values returns a function for the Values field of quick.Config that generates the arguments of
+// the function f with Value, so that quick.Check and quick.CheckEqual can test functions taking
+// Null types or structs containing them:
+//
+//	err := quick.Check(f, &quick.Config{Values: jsontypetest.Values(f)})
+func Values(f any) func(args []reflect.Value, r *rand.Rand) {
+	t := reflect.TypeOf(f)
+	return func(args []reflect.Value, r *rand.Rand) {
+		for i := range args {
+			v, ok := Value(t.In(i), r)
+			if !ok {
+				panic("jsontypetest: cannot generate a value of type " + t.In(i).String())
+			}
+			args[i] = v
+		}
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
@@ -0,0 +1,235 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package jsontype
+
+import (
+	"encoding"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"reflect"
+	"time"
+)
+
+// The methods in this file implement the streaming interfaces of encoding/json/v2, which is available when building
+// with GOEXPERIMENT=jsonv2. They read values directly from the token stream instead of re-invoking json.Unmarshal on
+// a copy of the input, and report values that cannot be decoded as a *DecodeError like the UnmarshalJSON methods.
+// As the value is not copied, the Value of the DecodeError is the value reported by encoding/json/v2, which is empty
+// unless the value is a number. Absent fields are omitted by encoding/json/v2 when tagged with omitzero, using IsZero.
+
+// unmarshalDecode decodes the next value of dec into v with the options of dec. It returns a *DecodeError with
+// the expected kind of JSON value if the value cannot be decoded into v, and syntax errors as they are.
+func unmarshalDecode(dec *jsontext.Decoder, v any, expected string) error {
+	err := json.UnmarshalDecode(dec, v)
+	if err == nil || errors.As(err, new(*jsontext.SyntacticError)) {
+		return err
+	}
+	var value []byte
+	if se, ok := err.(*json.SemanticError); ok {
+		value = se.JSONValue
+	}
+	return newDecodeError(value, expected, err)
+}
+
+// decodeQuoted decodes a JSON string with the UnmarshalText method of u, as unmarshalQuoted does for
+// UnmarshalJSON, so that the boolean and number types can be used as map keys. It reports whether the
+// next value of dec is a string.
+func decodeQuoted(dec *jsontext.Decoder, u encoding.TextUnmarshaler, expected string) (bool, error) {
+	if dec.PeekKind() != '"' {
+		return false, nil
+	}
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return true, err
+	}
+	if err := u.UnmarshalText([]byte(tok.String())); err != nil {
+		return true, newDecodeError(nil, expected, err)
+	}
+	return true, nil
+}
+
+// decodeInt decodes the next value of dec as a signed integer of bitSize bits for dst. A number is read as a token
+// and rejected like by unmarshalInt if it is out of range or not an integer; other values are decoded into dst by
+// json.UnmarshalDecode, which applies the options of dec and reports the error.
+func decodeInt(dec *jsontext.Decoder, dst any, bitSize int) (int64, error) {
+	if dec.PeekKind() != '0' {
+		if err := unmarshalDecode(dec, dst, "integer"); err != nil {
+			return 0, err
+		}
+		return reflect.ValueOf(dst).Elem().Int(), nil
+	}
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return 0, err
+	}
+	return parseIntNumber([]byte(tok.String()), dst, bitSize)
+}
+
+// decodeUint decodes the next value of dec as an unsigned integer of bitSize bits for dst, like decodeInt.
+func decodeUint(dec *jsontext.Decoder, dst any, bitSize int) (uint64, error) {
+	if dec.PeekKind() != '0' {
+		if err := unmarshalDecode(dec, dst, "integer"); err != nil {
+			return 0, err
+		}
+		return reflect.ValueOf(dst).Elem().Uint(), nil
+	}
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return 0, err
+	}
+	return parseUintNumber([]byte(tok.String()), dst, bitSize)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (nb *NullBool) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		nb.Bool, nb.Valid, nb.Present = false, false, true
+		return nil
+	}
+	if ok, err := decodeQuoted(dec, nb, "boolean"); ok {
+		return err
+	}
+	if err := unmarshalDecode(dec, &nb.Bool, "boolean"); err != nil {
+		return err
+	}
+	nb.Valid, nb.Present = true, true
+	return nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (nb NullBool) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !nb.Present || !nb.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.Bool(nb.Bool))
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (nf *NullFloat64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		nf.Float64, nf.Valid, nf.Present = 0, false, true
+		return nil
+	}
+	if ok, err := decodeQuoted(dec, nf, "number"); ok {
+		return err
+	}
+	if err := unmarshalDecode(dec, &nf.Float64, "number"); err != nil {
+		return err
+	}
+	nf.Valid, nf.Present = true, true
+	return nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (nf NullFloat64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !nf.Present || !nf.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return json.MarshalEncode(enc, nf.Float64)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (ni *NullInt) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		ni.Int, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	if ok, err := decodeQuoted(dec, ni, "integer"); ok {
+		return err
+	}
+	if err := unmarshalDecode(dec, &ni.Int, "integer"); err != nil {
+		return err
+	}
+	ni.Valid, ni.Present = true, true
+	return nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (ni NullInt) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !ni.Present || !ni.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.Int(int64(ni.Int)))
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (ns *NullString) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		ns.String, ns.Valid, ns.Present = "", false, true
+		return nil
+	}
+	if err := unmarshalDecode(dec, &ns.String, "string"); err != nil {
+		return err
+	}
+	ns.Valid, ns.Present = true, true
+	return nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (ns NullString) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !ns.Present || !ns.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.String(ns.String))
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (nt *NullTime) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		nt.Time, nt.Valid, nt.Present = time.Time{}, false, true
+		return nil
+	}
+	if err := unmarshalDecode(dec, &nt.Time, "string"); err != nil {
+		return err
+	}
+	nt.Valid, nt.Present = true, true
+	return nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (nt NullTime) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !nt.Present || !nt.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return json.MarshalEncode(enc, nt.Time)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (nt *Null[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		var zero T
+		nt.Value, nt.Valid, nt.Present = zero, false, true
+		return nil
+	}
+	if err := unmarshalDecode(dec, &nt.Value, jsonKind(reflect.TypeOf(&nt.Value).Elem())); err != nil {
+		return err
+	}
+	nt.Valid, nt.Present = true, true
+	return nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (nt Null[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !nt.Present || !nt.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return json.MarshalEncode(enc, nt.Value)
+}
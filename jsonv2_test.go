@@ -0,0 +1,251 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package jsontype
+
+import (
+	jsonv1 "encoding/json"
+	"encoding/json/v2"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type jsonV2Person struct {
+	Active NullBool       `json:"active,omitzero"`
+	Score  NullFloat64    `json:"score,omitzero"`
+	Age    NullInt        `json:"age,omitzero"`
+	Name   NullString     `json:"name,omitzero"`
+	Born   NullTime       `json:"born,omitzero"`
+	Tags   Null[[]string] `json:"tags,omitzero"`
+	Level  NullUint8      `json:"level,omitzero"`
+}
+
+// Test the UnmarshalJSONFrom methods of the Null types
+func TestJSONV2_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  jsonV2Person
+		expectErr bool
+	}{
+		{
+			name:  "Valid values",
+			input: `{"active":true,"score":4.56,"age":123,"name":"hello","born":"2024-01-01T00:00:00Z","tags":["a"]}`,
+			expected: jsonV2Person{
+				Active: NullBool{Bool: true, Valid: true, Present: true},
+				Score:  NullFloat64{Float64: 4.56, Valid: true, Present: true},
+				Age:    NullInt{Int: 123, Valid: true, Present: true},
+				Name:   NullString{String: "hello", Valid: true, Present: true},
+				Born:   NullTime{Time: time.Date(2024, 01, 01, 00, 00, 00, 00, time.UTC), Valid: true, Present: true},
+				Tags:   Null[[]string]{Value: []string{"a"}, Valid: true, Present: true},
+			},
+		},
+		{
+			name:  "Null values",
+			input: `{"active":null,"score":null,"age":null,"name":null,"born":null,"tags":null}`,
+			expected: jsonV2Person{
+				Active: NullBool{Present: true},
+				Score:  NullFloat64{Present: true},
+				Age:    NullInt{Present: true},
+				Name:   NullString{Present: true},
+				Born:   NullTime{Present: true},
+				Tags:   Null[[]string]{Present: true},
+			},
+		},
+		{
+			name:     "Absent values",
+			input:    `{}`,
+			expected: jsonV2Person{},
+		},
+		{
+			name:      "Invalid type",
+			input:     `{"age":"hello"}`,
+			expectErr: true,
+		},
+		{
+			name:      "Out of range",
+			input:     `{"level":256}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p jsonV2Person
+			err := json.Unmarshal([]byte(tt.input), &p)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Unmarshal() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if tt.expectErr {
+				return
+			}
+			if p.Active != tt.expected.Active || p.Score != tt.expected.Score || p.Age != tt.expected.Age ||
+				p.Name != tt.expected.Name || p.Born != tt.expected.Born || len(p.Tags.Value) != len(tt.expected.Tags.Value) ||
+				p.Tags.Valid != tt.expected.Tags.Valid || p.Tags.Present != tt.expected.Tags.Present || p.Level != tt.expected.Level {
+				t.Errorf("Unmarshal() = %+v, expected %+v", p, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the MarshalJSONTo methods of the Null types
+func TestJSONV2_Marshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    jsonV2Person
+		expected string
+	}{
+		{
+			name: "Valid values",
+			input: jsonV2Person{
+				Active: NullBool{Bool: true, Valid: true, Present: true},
+				Score:  NullFloat64{Float64: 4.56, Valid: true, Present: true},
+				Age:    NullInt{Int: 123, Valid: true, Present: true},
+				Name:   NullString{String: "hello", Valid: true, Present: true},
+				Born:   NullTime{Time: time.Date(2024, 01, 01, 00, 00, 00, 00, time.UTC), Valid: true, Present: true},
+				Tags:   Null[[]string]{Value: []string{"a"}, Valid: true, Present: true},
+				Level:  NullUint8{Uint8: 255, Valid: true, Present: true},
+			},
+			expected: `{"active":true,"score":4.56,"age":123,"name":"hello","born":"2024-01-01T00:00:00Z","tags":["a"],"level":255}`,
+		},
+		{
+			name: "Null values",
+			input: jsonV2Person{
+				Active: NullBool{Present: true},
+				Age:    NullInt{Int: 123, Valid: false, Present: true},
+				Tags:   Null[[]string]{Present: true},
+			},
+			expected: `{"active":null,"age":null,"tags":null}`,
+		},
+		{
+			name:     "Absent values",
+			input:    jsonV2Person{Age: NullInt{Int: 123, Valid: true}},
+			expected: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := json.Marshal(tt.input)
+			if err != nil {
+				t.Errorf("Marshal() error = %v", err)
+				return
+			}
+			if string(result) != tt.expected {
+				t.Errorf("Marshal() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that the UnmarshalJSONFrom methods return a DecodeError, with both the v1 and the v2 API. The value is only
+// known for numbers.
+func TestJSONV2_DecodeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst      func() any
+		input    string
+		expected string
+		value    string
+	}{
+		{name: "NullBool", dst: func() any { return &struct{ A NullBool }{} }, input: `1`, expected: "boolean"},
+		{name: "NullFloat64", dst: func() any { return &struct{ A NullFloat64 }{} }, input: `"x"`, expected: "number"},
+		{name: "NullInt", dst: func() any { return &struct{ A NullInt }{} }, input: `"x"`, expected: "integer"},
+		{name: "NullInt8", dst: func() any { return &struct{ A NullInt8 }{} }, input: `300`, expected: "integer", value: `300`},
+		{name: "NullString", dst: func() any { return &struct{ A NullString }{} }, input: `{}`, expected: "string"},
+		{name: "NullTime", dst: func() any { return &struct{ A NullTime }{} }, input: `"yesterday"`, expected: "string"},
+		{name: "Null[[]int]", dst: func() any { return &struct{ A Null[[]int] }{} }, input: `{}`, expected: "array"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := []byte(`{"A":` + tt.input + `}`)
+			for api, unmarshal := range map[string]func([]byte, any) error{
+				"v1": jsonv1.Unmarshal,
+				"v2": func(b []byte, v any) error { return json.Unmarshal(b, v) },
+			} {
+				var de *DecodeError
+				if err := unmarshal(input, tt.dst()); !errors.As(err, &de) || de.Expected != tt.expected || string(de.Value) != tt.value {
+					t.Errorf("%s Unmarshal() error = %v, expected a *DecodeError expecting %s", api, err, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+// Test that the UnmarshalJSONFrom methods of the integer types decode from the token stream, reject numbers out of
+// range with the bound and decode strings by their text form
+func TestJSONV2_Ints(t *testing.T) {
+	type ints struct {
+		Small NullInt8   `json:"small"`
+		Big   NullInt64  `json:"big"`
+		Level NullUint16 `json:"level"`
+	}
+	tests := []struct {
+		name     string
+		input    string
+		opts     []json.Options
+		expected ints
+		message  string
+	}{
+		{
+			name:  "Numbers and null",
+			input: `{"small":-128,"big":9223372036854775807,"level":null}`,
+			expected: ints{
+				Small: NullInt8{Int8: -128, Valid: true, Present: true},
+				Big:   NullInt64{Int64: 9223372036854775807, Valid: true, Present: true},
+				Level: NullUint16{Present: true},
+			},
+		},
+		{
+			name:    "Out of range",
+			input:   `{"big":9223372036854775808}`,
+			message: "jsontype: cannot unmarshal 9223372036854775808: expected integer or null: out of range for int64, maximum is 9223372036854775807",
+		},
+		{
+			name:    "Negative unsigned",
+			input:   `{"level":-1}`,
+			message: "jsontype: cannot unmarshal -1: expected integer or null: out of range for uint16, minimum is 0",
+		},
+		{
+			name:    "Fraction",
+			input:   `{"small":1.5}`,
+			message: "jsontype: cannot unmarshal 1.5: expected integer or null: not an integer",
+		},
+		{
+			name:    "String",
+			input:   `{"small":"x"}`,
+			message: `jsontype: cannot unmarshal value: expected integer or null: strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			name:     "Quoted",
+			input:    `{"small":"5","level":"null"}`,
+			expected: ints{Small: NullInt8{Int8: 5, Valid: true, Present: true}, Level: NullUint16{Present: true}},
+		},
+		{
+			name:     "String with StringifyNumbers",
+			input:    `{"small":"5","level":"7"}`,
+			opts:     []json.Options{json.StringifyNumbers(true)},
+			expected: ints{Small: NullInt8{Int8: 5, Valid: true, Present: true}, Level: NullUint16{Uint16: 7, Valid: true, Present: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v ints
+			err := json.Unmarshal([]byte(tt.input), &v, tt.opts...)
+			if tt.message != "" {
+				var de *DecodeError
+				if !errors.As(err, &de) || !strings.HasPrefix(de.Error(), tt.message) {
+					t.Errorf("Unmarshal() error = %v, expected %s", err, tt.message)
+				}
+				return
+			}
+			if err != nil || v != tt.expected {
+				t.Errorf("Unmarshal() = %+v, %v, expected %+v", v, err, tt.expected)
+			}
+		})
+	}
+}
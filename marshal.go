@@ -0,0 +1,235 @@
+package jsontype
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+var (
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	isZeroerType      = reflect.TypeOf((*interface{ IsZero() bool })(nil)).Elem()
+)
+
+// Marshal returns the JSON encoding of v. It behaves like json.Marshal, except that struct fields
+// of the Null types that are absent are omitted, while present null fields are written as null.
+// This gives the same result as tagging every Null field with omitzero, also on toolchains before
+// Go 1.24.
+//
+// Marshal supports the omitempty, omitzero and string tag options. Values implementing
+// json.Marshaler or encoding.TextMarshaler are marshaled by json.Marshal, so absent Null fields
+// inside such values are not omitted.
+func Marshal(v any) ([]byte, error) {
+	var e encoder
+	if err := e.encode(reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+type encoder struct {
+	bytes.Buffer
+}
+
+func (e *encoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		e.WriteString("null")
+		return nil
+	}
+	if value, valid, present, ok := typeinfo.NullParts(v); ok {
+		if !present.Bool() || !valid.Bool() {
+			e.WriteString("null")
+			return nil
+		}
+		return e.encode(value)
+	}
+	if implementsMarshaler(v) {
+		return e.marshal(v)
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			e.WriteString("null")
+			return nil
+		}
+		return e.encode(v.Elem())
+	case reflect.Struct:
+		return e.encodeStruct(v, nil)
+	case reflect.Slice:
+		if v.IsNil() {
+			e.WriteString("null")
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return e.marshal(v)
+		}
+		return e.encodeArray(v)
+	case reflect.Array:
+		return e.encodeArray(v)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || v.Type().Key().Implements(textMarshalerType) {
+			return e.marshal(v)
+		}
+		return e.encodeMap(v)
+	default:
+		return e.marshal(v)
+	}
+}
+
+// marshal writes the encoding of v by json.Marshal.
+func (e *encoder) marshal(v reflect.Value) error {
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	e.Write(b)
+	return nil
+}
+
+// encodeStruct writes the encoding of the struct v, with only the fields in set if it is not nil.
+func (e *encoder) encodeStruct(v reflect.Value, set fieldSet) error {
+	e.WriteByte('{')
+	first := true
+	for _, f := range typeinfo.Fields(v.Type(), "json") {
+		sub, selected := set[f.Name]
+		if set != nil && !selected {
+			continue
+		}
+		fv, ok := typeinfo.FieldByIndex(v, f.Index)
+		if !ok || omitField(f, fv) {
+			continue
+		}
+		if !first {
+			e.WriteByte(',')
+		}
+		first = false
+		if err := e.marshal(reflect.ValueOf(f.Name)); err != nil {
+			return err
+		}
+		e.WriteByte(':')
+		if sub != nil {
+			if err := e.encodeFields(fv, sub); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := e.encodeField(f, fv); err != nil {
+			return err
+		}
+	}
+	e.WriteByte('}')
+	return nil
+}
+
+// encodeField writes the encoding of the value fv of struct field f.
+func (e *encoder) encodeField(f typeinfo.Field, fv reflect.Value) error {
+	if !f.HasOption("string") {
+		return e.encode(fv)
+	}
+	switch fv.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		b, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return err
+		}
+		return e.marshal(reflect.ValueOf(string(b)))
+	}
+	return e.encode(fv)
+}
+
+func (e *encoder) encodeArray(v reflect.Value) error {
+	e.WriteByte('[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			e.WriteByte(',')
+		}
+		if err := e.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	e.WriteByte(']')
+	return nil
+}
+
+func (e *encoder) encodeMap(v reflect.Value) error {
+	if v.IsNil() {
+		e.WriteString("null")
+		return nil
+	}
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	e.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			e.WriteByte(',')
+		}
+		if err := e.marshal(reflect.ValueOf(k.String())); err != nil {
+			return err
+		}
+		e.WriteByte(':')
+		if err := e.encode(v.MapIndex(k)); err != nil {
+			return err
+		}
+	}
+	e.WriteByte('}')
+	return nil
+}
+
+// omitField reports whether struct field f with value fv is left out of the encoding.
+func omitField(f typeinfo.Field, fv reflect.Value) bool {
+	if _, _, present, ok := typeinfo.NullParts(fv); ok && !present.Bool() {
+		return true
+	}
+	if f.HasOption("omitempty") && isEmptyValue(fv) {
+		return true
+	}
+	if f.HasOption("omitzero") {
+		if fv.Type().Implements(isZeroerType) {
+			if fv.Kind() != reflect.Pointer || !fv.IsNil() {
+				return fv.Interface().(interface{ IsZero() bool }).IsZero()
+			}
+		}
+		return fv.IsZero()
+	}
+	return false
+}
+
+// isEmptyValue reports whether v is empty as defined for the omitempty tag option of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// implementsMarshaler reports whether v, or a pointer to v if it is addressable, implements
+// json.Marshaler or encoding.TextMarshaler.
+func implementsMarshaler(v reflect.Value) bool {
+	t := v.Type()
+	if t.Kind() == reflect.Interface {
+		return false
+	}
+	if t.Implements(marshalerType) || t.Implements(textMarshalerType) {
+		return true
+	}
+	if v.CanAddr() {
+		pt := reflect.PointerTo(t)
+		return pt.Implements(marshalerType) || pt.Implements(textMarshalerType)
+	}
+	return false
+}
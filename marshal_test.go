@@ -0,0 +1,215 @@
+package jsontype
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type marshalAddress struct {
+	City NullString `json:"city"`
+	Zip  NullString `json:"zip"`
+}
+
+type marshalPerson struct {
+	Name     NullString           `json:"name"`
+	Age      NullInt              `json:"age"`
+	Born     NullTime             `json:"born"`
+	Address  *marshalAddress      `json:"address,omitempty"`
+	Contacts []marshalAddress     `json:"contacts,omitempty"`
+	Labels   map[string]NullInt   `json:"labels,omitempty"`
+	Extra    Null[marshalAddress] `json:"extra"`
+	Count    int                  `json:"count,string"`
+	Note     string               `json:"note,omitempty"`
+	Zero     time.Time            `json:"zero,omitzero"`
+	Internal string               `json:"-"`
+}
+
+type MarshalBase struct {
+	ID      NullInt `json:"id"`
+	Version int     `json:"version"`
+}
+
+type marshalEmbedded struct {
+	*MarshalBase
+	Name NullString `json:"name"`
+}
+
+// marshalShadowed hides the id field of MarshalBase with its own.
+type marshalShadowed struct {
+	MarshalBase
+	ID NullInt `json:"id"`
+}
+
+type MarshalTagged struct {
+	Code NullString `json:"Name"`
+	Note NullString
+}
+
+type MarshalUntagged struct {
+	Name NullString
+	Note NullString
+}
+
+// marshalAmbiguous embeds two structs with a Note field, which hide each other, and two structs
+// with a Name field, of which only one is named by its tag.
+type marshalAmbiguous struct {
+	MarshalTagged
+	MarshalUntagged
+}
+
+// Test the Marshal function
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     any
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "Absent fields",
+			input:    marshalPerson{},
+			expected: `{"count":"0"}`,
+		},
+		{
+			name: "Valid and null fields",
+			input: &marshalPerson{
+				Name: NullString{String: "John", Valid: true, Present: true},
+				Age:  NullInt{Present: true},
+				Born: NullTime{Time: time.Date(2024, 01, 01, 00, 00, 00, 00, time.UTC), Valid: true, Present: true},
+			},
+			expected: `{"name":"John","age":null,"born":"2024-01-01T00:00:00Z","count":"0"}`,
+		},
+		{
+			name: "Nested values",
+			input: marshalPerson{
+				Address:  &marshalAddress{City: NullString{String: "Boston", Valid: true, Present: true}},
+				Contacts: []marshalAddress{{Zip: NullString{Present: true}}},
+				Labels:   map[string]NullInt{"b": {Int: 2, Valid: true, Present: true}, "a": {Present: true}},
+				Extra:    Null[marshalAddress]{Value: marshalAddress{City: NullString{String: "<x>", Valid: true, Present: true}}, Valid: true, Present: true},
+				Count:    3,
+				Note:     "note",
+			},
+			expected: `{"address":{"city":"Boston"},"contacts":[{"zip":null}],"labels":{"a":null,"b":2},"extra":{"city":"\u003cx\u003e"},"count":"3","note":"note"}`,
+		},
+		{
+			name:     "Embedded pointer",
+			input:    marshalEmbedded{MarshalBase: &MarshalBase{ID: NullInt{Int: 1, Valid: true, Present: true}}, Name: NullString{Present: true}},
+			expected: `{"id":1,"version":0,"name":null}`,
+		},
+		{
+			name:     "Nil embedded pointer",
+			input:    marshalEmbedded{Name: NullString{String: "John", Valid: true, Present: true}},
+			expected: `{"name":"John"}`,
+		},
+		{
+			name:     "Null type",
+			input:    NullInt{Int: 1, Valid: true},
+			expected: `null`,
+		},
+		{
+			name:     "Nil",
+			input:    nil,
+			expected: `null`,
+		},
+		{
+			name:      "Unsupported type",
+			input:     map[string]any{"f": func() {}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Marshal(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Marshal() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if string(result) != tt.expected {
+				t.Errorf("Marshal() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that Marshal flattens embedded pointers to structs like json.Marshal
+func TestMarshal_EmbeddedPointer(t *testing.T) {
+	name := NullString{String: "John", Valid: true, Present: true}
+	for _, input := range []marshalEmbedded{{MarshalBase: &MarshalBase{ID: NullInt{Present: true}, Version: 2}, Name: name}, {Name: name}} {
+		result, err := Marshal(input)
+		if err != nil {
+			t.Errorf("Marshal() error = %v", err)
+			continue
+		}
+		expected, _ := json.Marshal(input)
+		if string(result) != string(expected) {
+			t.Errorf("Marshal() = %s, expected %s like json.Marshal", result, expected)
+		}
+	}
+}
+
+// Test that Marshal applies the rules of json.Marshal to fields with the same name
+func TestMarshal_Dominance(t *testing.T) {
+	set := func(s string) NullString { return NullString{String: s, Valid: true, Present: true} }
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{
+			name:     "Shadowed field",
+			input:    marshalShadowed{MarshalBase: MarshalBase{ID: NullInt{Int: 1, Valid: true, Present: true}}, ID: NullInt{Int: 2, Valid: true, Present: true}},
+			expected: `{"version":0,"id":2}`,
+		},
+		{
+			name:     "Tagged and ambiguous fields",
+			input:    marshalAmbiguous{MarshalTagged{Code: set("tagged"), Note: set("a")}, MarshalUntagged{Name: set("untagged"), Note: set("b")}},
+			expected: `{"Name":"tagged"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Marshal(tt.input)
+			if err != nil {
+				t.Errorf("Marshal() error = %v", err)
+				return
+			}
+			expected, _ := json.Marshal(tt.input)
+			if string(result) != tt.expected || string(expected) != tt.expected {
+				t.Errorf("Marshal() = %s, json.Marshal() = %s, expected %s", result, expected, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the IsZero method of the Null types
+func TestIsZero(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{ IsZero() bool }
+		expected bool
+	}{
+		{name: "NullBool absent", input: NullBool{Bool: true, Valid: true}, expected: true},
+		{name: "NullBool null", input: NullBool{Present: true}, expected: false},
+		{name: "NullFloat64 absent", input: NullFloat64{}, expected: true},
+		{name: "NullFloat64 valid", input: NullFloat64{Valid: true, Present: true}, expected: false},
+		{name: "NullInt absent", input: NullInt{}, expected: true},
+		{name: "NullInt valid", input: NullInt{Valid: true, Present: true}, expected: false},
+		{name: "NullString absent", input: NullString{}, expected: true},
+		{name: "NullString null", input: NullString{Present: true}, expected: false},
+		{name: "NullTime absent", input: NullTime{}, expected: true},
+		{name: "NullTime valid", input: NullTime{Valid: true, Present: true}, expected: false},
+		{name: "Null[int] absent", input: Null[int]{}, expected: true},
+		{name: "Null[int] null", input: Null[int]{Present: true}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.input.IsZero(); result != tt.expected {
+				t.Errorf("IsZero() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
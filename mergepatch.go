@@ -0,0 +1,132 @@
+package jsontype
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// MergePatch applies the JSON merge patch (RFC 7386) patch to the JSON document original and
+// returns the patched document.
+//
+// Objects in the patch are merged recursively into the original, members with a null value
+// are removed from the original and any other value replaces the original value. An empty
+// original is treated as an absent document. Numbers are preserved as written; members of
+// objects in the result are sorted by name.
+func MergePatch(original, patch []byte) ([]byte, error) {
+	var target any
+	if len(bytes.TrimSpace(original)) > 0 {
+		if err := decodeJSON(original, &target); err != nil {
+			return nil, fmt.Errorf("jsontype: invalid merge patch target: %w", err)
+		}
+	}
+	var p any
+	if err := decodeJSON(patch, &p); err != nil {
+		return nil, fmt.Errorf("jsontype: invalid merge patch: %w", err)
+	}
+	return encodeJSON(mergePatch(target, p))
+}
+
+// ApplyMergePatch applies the JSON merge patch (RFC 7386) patch, such as the body of an
+// application/merge-patch+json request, to the value pointed to by v.
+//
+// The value is marshaled with Marshal, patched with MergePatch and unmarshaled into a new value,
+// so members removed by the patch leave their fields at the zero value and absent Null fields
+// that are not in the patch stay absent. Only if that succeeds are the fields encoded as JSON
+// copied onto v; unexported fields and fields tagged with json:"-" keep their value, and v is not
+// modified at all if an error is returned.
+func ApplyMergePatch(v any, patch []byte) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("jsontype: ApplyMergePatch requires a non-nil pointer, got %T", v)
+	}
+	original, err := Marshal(v)
+	if err != nil {
+		return err
+	}
+	patched, err := MergePatch(original, patch)
+	if err != nil {
+		return err
+	}
+	result := reflect.New(rv.Elem().Type())
+	if err := json.Unmarshal(patched, result.Interface()); err != nil {
+		return err
+	}
+	copyEncoded(rv.Elem(), result.Elem())
+	return nil
+}
+
+// copyEncoded sets dst to src, both of the same type, but only the fields of structs that are
+// encoded as JSON, so that the other fields of dst keep their value.
+func copyEncoded(dst, src reflect.Value) {
+	switch {
+	case dst.Kind() == reflect.Pointer && !dst.IsNil() && !src.IsNil() && walksStruct(dst.Type().Elem()):
+		copyEncoded(dst.Elem(), src.Elem())
+	case walksStruct(dst.Type()):
+		for _, f := range typeinfo.Fields(dst.Type(), "json") {
+			sf, inSrc := typeinfo.FieldByIndex(src, f.Index)
+			df, inDst := typeinfo.FieldByIndex(dst, f.Index)
+			if inSrc && !inDst {
+				df, inDst = typeinfo.SettableFieldByIndex(dst, f.Index)
+			}
+			if inDst {
+				copyEncoded(df, sf)
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// walksStruct reports whether t is a struct whose fields are encoded one by one, rather than a
+// Null type or a type with its own JSON or text encoding.
+func walksStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !typeinfo.IsNull(t) && !implementsUnmarshaler(t)
+}
+
+// mergePatch implements the MergePatch function of RFC 7386, section 2.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for name, value := range p {
+		if value == nil {
+			delete(t, name)
+			continue
+		}
+		t[name] = mergePatch(t[name], value)
+	}
+	return t
+}
+
+// decodeJSON unmarshals a single JSON value from data into v, keeping numbers as json.Number.
+func decodeJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data[dec.InputOffset():])) > 0 {
+		return fmt.Errorf("invalid character after top-level value")
+	}
+	return nil
+}
+
+// encodeJSON marshals v without escaping HTML characters.
+func encodeJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
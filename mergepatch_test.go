@@ -0,0 +1,188 @@
+package jsontype
+
+import (
+	"testing"
+)
+
+// Test the MergePatch function with the examples of RFC 7386, appendix A
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		original  string
+		patch     string
+		expected  string
+		expectErr bool
+	}{
+		{name: "Replace member", original: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "Add member", original: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{name: "Remove member", original: `{"a":"b"}`, patch: `{"a":null}`, expected: `{}`},
+		{name: "Remove one of two members", original: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{name: "Replace array with string", original: `{"a":["b"]}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "Replace string with array", original: `{"a":"c"}`, patch: `{"a":["b"]}`, expected: `{"a":["b"]}`},
+		{name: "Nested object", original: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, expected: `{"a":{"b":"d"}}`},
+		{name: "Replace array of objects", original: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, expected: `{"a":[1]}`},
+		{name: "Replace array", original: `["a","b"]`, patch: `["c","d"]`, expected: `["c","d"]`},
+		{name: "Object replaced by array", original: `{"a":"b"}`, patch: `["c"]`, expected: `["c"]`},
+		{name: "Object replaced by null", original: `{"a":"foo"}`, patch: `null`, expected: `null`},
+		{name: "Object replaced by string", original: `{"a":"foo"}`, patch: `"bar"`, expected: `"bar"`},
+		{name: "Null member added", original: `{"e":null}`, patch: `{"a":1}`, expected: `{"a":1,"e":null}`},
+		{name: "Array replaced by object", original: `[1,2]`, patch: `{"a":"b","c":null}`, expected: `{"a":"b"}`},
+		{name: "Nested null removed", original: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, expected: `{"a":{"bb":{}}}`},
+		{name: "Empty original", original: ``, patch: `{"a":{"b":null}}`, expected: `{"a":{}}`},
+		{name: "Numbers preserved", original: `{"a":1.50}`, patch: `{"b":12345678901234567890}`, expected: `{"a":1.50,"b":12345678901234567890}`},
+		{name: "HTML not escaped", original: `{}`, patch: `{"a":"<b>"}`, expected: `{"a":"<b>"}`},
+		{name: "Invalid original", original: `{`, patch: `{}`, expectErr: true},
+		{name: "Invalid patch", original: `{}`, patch: `{"a":1}}`, expectErr: true},
+		{name: "Empty patch", original: `{}`, patch: ``, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MergePatch([]byte(tt.original), []byte(tt.patch))
+			if (err != nil) != tt.expectErr {
+				t.Errorf("MergePatch() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if string(result) != tt.expected {
+				t.Errorf("MergePatch() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+type mergePatchAddress struct {
+	Street string `json:"street,omitempty"`
+	City   string `json:"city,omitempty"`
+}
+
+type mergePatchPerson struct {
+	Name    string             `json:"name"`
+	Tags    []string           `json:"tags,omitempty"`
+	Address *mergePatchAddress `json:"address,omitempty"`
+	Age     NullInt            `json:"age"`
+}
+
+// Test the ApplyMergePatch function
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		patch     string
+		expected  mergePatchPerson
+		expectErr bool
+	}{
+		{
+			name:  "Replace and merge",
+			patch: `{"name":"Jane","address":{"city":"Boston"}}`,
+			expected: mergePatchPerson{
+				Name:    "Jane",
+				Tags:    []string{"a", "b"},
+				Address: &mergePatchAddress{Street: "Main Street", City: "Boston"},
+				Age:     NullInt{Int: 42, Valid: true, Present: true},
+			},
+		},
+		{
+			name:  "Remove members",
+			patch: `{"tags":null,"address":{"street":null,"city":null},"age":null}`,
+			expected: mergePatchPerson{
+				Name:    "John",
+				Address: &mergePatchAddress{},
+				Age:     NullInt{Int: 0, Valid: false, Present: false},
+			},
+		},
+		{
+			name:      "Invalid patch",
+			patch:     `{"name":`,
+			expectErr: true,
+		},
+		{
+			name:      "Type mismatch",
+			patch:     `{"name":123}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := mergePatchPerson{
+				Name:    "John",
+				Tags:    []string{"a", "b"},
+				Address: &mergePatchAddress{Street: "Main Street", City: "New York"},
+				Age:     NullInt{Int: 42, Valid: true, Present: true},
+			}
+			err := ApplyMergePatch(&p, []byte(tt.patch))
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ApplyMergePatch() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if tt.expectErr {
+				if p.Name != "John" || len(p.Tags) != 2 || p.Address == nil || p.Address.City != "New York" || !p.Age.Valid {
+					t.Errorf("ApplyMergePatch() = %+v, expected the value to be unchanged", p)
+				}
+				return
+			}
+			if p.Name != tt.expected.Name || len(p.Tags) != len(tt.expected.Tags) || p.Age != tt.expected.Age ||
+				(p.Address == nil) != (tt.expected.Address == nil) || (p.Address != nil && *p.Address != *tt.expected.Address) {
+				t.Errorf("ApplyMergePatch() = %+v, expected %+v", p, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that ApplyMergePatch keeps absent fields absent
+func TestApplyMergePatch_Absent(t *testing.T) {
+	p := mergePatchPerson{Name: "John"}
+	if err := ApplyMergePatch(&p, []byte(`{"name":"Jane"}`)); err != nil {
+		t.Errorf("ApplyMergePatch() error = %v", err)
+		return
+	}
+	if p.Name != "Jane" || p.Age != (NullInt{}) {
+		t.Errorf("ApplyMergePatch() = %+v, expected name Jane and absent age", p)
+	}
+}
+
+type mergePatchAccount struct {
+	Name    string `json:"name"`
+	Hash    string `json:"-"`
+	id      int
+	Address *mergePatchAddress `json:"address"`
+	Home    struct {
+		City string `json:"city"`
+		note string
+	} `json:"home"`
+}
+
+// Test that ApplyMergePatch keeps the fields that are not encoded as JSON
+func TestApplyMergePatch_Hidden(t *testing.T) {
+	address := &mergePatchAddress{Street: "Main Street", City: "New York"}
+	a := mergePatchAccount{Name: "bob", Hash: "secret", id: 7, Address: address}
+	a.Home.City, a.Home.note = "Boston", "garden"
+	if err := ApplyMergePatch(&a, []byte(`{"name":"alice","address":{"city":"Paris"},"home":{"city":"Rome"}}`)); err != nil {
+		t.Errorf("ApplyMergePatch() error = %v", err)
+		return
+	}
+	if a.Name != "alice" || a.Hash != "secret" || a.id != 7 || a.Home.City != "Rome" || a.Home.note != "garden" {
+		t.Errorf("ApplyMergePatch() = %+v, expected name alice with the hidden fields kept", a)
+	}
+	if a.Address != address || *a.Address != (mergePatchAddress{Street: "Main Street", City: "Paris"}) {
+		t.Errorf("ApplyMergePatch() address = %+v, expected Main Street, Paris", a.Address)
+	}
+
+	if err := ApplyMergePatch(&a, []byte(`{"name":"carol","home":{"city":1}}`)); err == nil {
+		t.Errorf("ApplyMergePatch() error = nil, expected an error for a number")
+	}
+	if a.Name != "alice" || a.Hash != "secret" || a.id != 7 || a.Home.City != "Rome" {
+		t.Errorf("ApplyMergePatch() = %+v, expected the value to be unchanged", a)
+	}
+}
+
+// Test that ApplyMergePatch patches the field hiding an embedded field and keeps the hidden one
+func TestApplyMergePatch_ShadowedField(t *testing.T) {
+	one, two := NullInt{Int: 1, Valid: true, Present: true}, NullInt{Int: 2, Valid: true, Present: true}
+	v := marshalShadowed{MarshalBase: MarshalBase{ID: one}, ID: two}
+	if err := ApplyMergePatch(&v, []byte(`{"id":5}`)); err != nil {
+		t.Fatalf("ApplyMergePatch() error = %v", err)
+	}
+	if v.ID.Int != 5 || v.MarshalBase.ID != one {
+		t.Errorf("ApplyMergePatch() = %+v, expected ID 5 with the hidden ID kept", v)
+	}
+}
@@ -0,0 +1,255 @@
+// Package schema generates JSON Schema (draft 2020-12, as used by OpenAPI 3.1) documents for Go types
+// containing the Null types of package jsontype, describing the JSON that json.Unmarshal accepts for
+// them.
+//
+// The Null types are described by the schema of their value type with null added to the allowed
+// types, so NullString becomes {"type":["string","null"]} and NullTime a date-time string or null.
+// Struct fields are named by their json tag and are listed as required only if their jsontype tag
+// contains the rule "required", as json.Unmarshal accepts missing fields. The rule "notnull" removes
+// null from the allowed types and the rule "forbidden" turns the property schema into false.
+//
+// Named struct types other than the root type are placed in $defs and referenced with $ref.
+// Pointers, slices and maps allow null, as json.Unmarshal accepts null for them. Types implementing
+// json.Marshaler are described by the empty schema, and types implementing encoding.TextMarshaler
+// by a string schema.
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// Draft is the URI of the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// defNameReplacer matches the characters of a Go type name that are not used in $defs names, such
+// as the brackets of generic types.
+var defNameReplacer = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// Generate returns the JSON Schema of type t, indented by two spaces.
+func Generate(t reflect.Type) ([]byte, error) {
+	if t == nil {
+		return nil, errors.New("schema: Generate requires a non-nil type")
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	g := generator{root: t, defs: map[string]any{}, names: map[string]reflect.Type{}}
+	s, err := g.schema(t)
+	if err != nil {
+		return nil, err
+	}
+	s["$schema"] = Draft
+	if len(g.defs) > 0 {
+		s["$defs"] = g.defs
+	}
+	return json.MarshalIndent(s, "", "  ")
+}
+
+type generator struct {
+	root     reflect.Type
+	rootSeen bool
+	defs     map[string]any
+	names    map[string]reflect.Type
+}
+
+// schema returns the schema of t.
+func (g *generator) schema(t reflect.Type) (map[string]any, error) {
+	if typeinfo.IsNull(t) {
+		s, err := g.schema(t.Field(0).Type)
+		if err != nil {
+			return nil, err
+		}
+		return nullable(s), nil
+	}
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		return map[string]any{}, nil
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		max := int64(1)<<(t.Bits()-1) - 1
+		return map[string]any{"type": "integer", "minimum": -max - 1, "maximum": max}, nil
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "minimum": 0, "maximum": uint64(math.MaxUint64) >> (64 - t.Bits())}, nil
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	case reflect.Pointer:
+		s, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(s), nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": []string{"string", "null"}, "contentEncoding": "base64"}, nil
+		}
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": []string{"array", "null"}, "items": items}, nil
+	case reflect.Array:
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": []string{"object", "null"}, "additionalProperties": values}, nil
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+	return nil, fmt.Errorf("schema: unsupported type %s", t)
+}
+
+// structSchema returns the schema of struct type t, or a reference to it.
+func (g *generator) structSchema(t reflect.Type) (map[string]any, error) {
+	if t == g.root {
+		if g.rootSeen {
+			return map[string]any{"$ref": "#"}, nil
+		}
+		g.rootSeen = true
+		return g.objectSchema(t)
+	}
+	if t.Name() == "" {
+		return g.objectSchema(t)
+	}
+
+	name := defNameReplacer.ReplaceAllString(t.Name(), "_")
+	ref := map[string]any{"$ref": "#/$defs/" + name}
+	if other, ok := g.names[name]; ok {
+		if other != t {
+			return nil, fmt.Errorf("schema: types %s and %s have the same name", other, t)
+		}
+		return ref, nil
+	}
+	g.names[name] = t
+	s, err := g.objectSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	g.defs[name] = s
+	return ref, nil
+}
+
+// objectSchema returns the object schema describing the fields of struct type t.
+func (g *generator) objectSchema(t reflect.Type) (map[string]any, error) {
+	properties := map[string]any{}
+	required := []string{}
+	for _, f := range typeinfo.Fields(t, "json") {
+		ft := f.StructField.Type
+		tag := f.StructField.Tag.Get("jsontype")
+		if tag != "" && !typeinfo.IsNull(ft) {
+			return nil, fmt.Errorf("schema: jsontype tag on field %s of %s, which is not a Null type", f.StructField.Name, t)
+		}
+
+		var rules []string
+		if tag != "" {
+			rules = strings.Split(tag, ",")
+		}
+		var notNull, forbidden bool
+		for _, rule := range rules {
+			switch rule = strings.TrimSpace(rule); rule {
+			case "required":
+				required = append(required, f.Name)
+			case "notnull":
+				notNull = true
+			case "forbidden":
+				forbidden = true
+			default:
+				return nil, fmt.Errorf("schema: unknown rule %q in jsontype tag of field %s of %s", rule, f.StructField.Name, t)
+			}
+		}
+
+		switch {
+		case forbidden:
+			properties[f.Name] = false
+		case notNull:
+			s, err := g.schema(ft.Field(0).Type)
+			if err != nil {
+				return nil, err
+			}
+			properties[f.Name] = s
+		case f.HasOption("string") && isScalar(ft):
+			properties[f.Name] = map[string]any{"type": "string"}
+		default:
+			s, err := g.schema(ft)
+			if err != nil {
+				return nil, err
+			}
+			properties[f.Name] = s
+		}
+	}
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s, nil
+}
+
+// nullable returns s with null added to the allowed types.
+func nullable(s map[string]any) map[string]any {
+	switch typ := s["type"].(type) {
+	case string:
+		s["type"] = []string{typ, "null"}
+		return s
+	case []string:
+		for _, t := range typ {
+			if t == "null" {
+				return s
+			}
+		}
+		s["type"] = append(typ, "null")
+		return s
+	}
+	if len(s) == 0 {
+		return s
+	}
+	return map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+}
+
+// isScalar reports whether values of type t are quoted by the string option of the json tag.
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
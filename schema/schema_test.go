@@ -0,0 +1,155 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mbe81/jsontype"
+)
+
+type address struct {
+	Street jsontype.NullString `json:"street"`
+	Zip    jsontype.Null[int]  `json:"zip,omitempty"`
+}
+
+type patchPerson struct {
+	ID       jsontype.NullInt64      `json:"id" jsontype:"forbidden"`
+	Name     jsontype.NullString     `json:"name" jsontype:"required,notnull"`
+	Age      jsontype.NullUint8      `json:"age"`
+	Score    jsontype.NullFloat64    `json:"score"`
+	Active   jsontype.NullBool       `json:"active"`
+	Birthday jsontype.NullTime       `json:"birthday"`
+	Address  jsontype.Null[address]  `json:"address"`
+	Tags     jsontype.Null[[]string] `json:"tags" jsontype:"required"`
+	IP       jsontype.Null[net.IP]   `json:"ip"`
+	Count    int                     `json:"count,string"`
+	Next     *patchPerson            `json:"next"`
+	Extra    map[string]any          `json:"extra"`
+	Data     []byte                  `json:"data"`
+	Window   [2]time.Time            `json:"window"`
+	Raw      json.RawMessage         `json:"raw"`
+	Internal string                  `json:"-"`
+}
+
+// Test the Generate function
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     reflect.Type
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "Scalar Null type",
+			input:    reflect.TypeOf(jsontype.NullString{}),
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["string","null"]}`,
+		},
+		{
+			name:     "NullTime",
+			input:    reflect.TypeOf(jsontype.NullTime{}),
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","format":"date-time","type":["string","null"]}`,
+		},
+		{
+			name:     "NullInt16",
+			input:    reflect.TypeOf(jsontype.NullInt16{}),
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","maximum":32767,"minimum":-32768,"type":["integer","null"]}`,
+		},
+		{
+			name:  "Struct",
+			input: reflect.TypeOf(&patchPerson{}),
+			expected: `{
+				"$schema":"https://json-schema.org/draft/2020-12/schema",
+				"$defs":{
+					"address":{"type":"object","properties":{
+						"street":{"type":["string","null"]},
+						"zip":{"type":["integer","null"]}
+					}}
+				},
+				"type":"object",
+				"properties":{
+					"id":false,
+					"name":{"type":"string"},
+					"age":{"type":["integer","null"],"minimum":0,"maximum":255},
+					"score":{"type":["number","null"]},
+					"active":{"type":["boolean","null"]},
+					"birthday":{"type":["string","null"],"format":"date-time"},
+					"address":{"anyOf":[{"$ref":"#/$defs/address"},{"type":"null"}]},
+					"tags":{"type":["array","null"],"items":{"type":"string"}},
+					"ip":{"type":["string","null"]},
+					"count":{"type":"string"},
+					"next":{"anyOf":[{"$ref":"#"},{"type":"null"}]},
+					"extra":{"type":["object","null"],"additionalProperties":{}},
+					"data":{"type":["string","null"],"contentEncoding":"base64"},
+					"window":{"type":"array","items":{"type":"string","format":"date-time"}},
+					"raw":{}
+				},
+				"required":["name","tags"]
+			}`,
+		},
+		{
+			name: "Tag on non-Null field",
+			input: reflect.TypeOf(struct {
+				A string `jsontype:"required"`
+			}{}),
+			expectErr: true,
+		},
+		{
+			name: "Unknown rule",
+			input: reflect.TypeOf(struct {
+				A jsontype.NullInt `jsontype:"optional"`
+			}{}),
+			expectErr: true,
+		},
+		{
+			name:      "Unsupported type",
+			input:     reflect.TypeOf(struct{ C chan int }{}),
+			expectErr: true,
+		},
+		{
+			name:      "Nil type",
+			input:     nil,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Generate(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Generate() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if tt.expectErr {
+				return
+			}
+			var got, expected any
+			if err := json.Unmarshal(result, &got); err != nil {
+				t.Errorf("Generate() returned invalid JSON: %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Generate() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that Generate returns the same output for the same type
+func TestGenerate_Deterministic(t *testing.T) {
+	a, err := Generate(reflect.TypeOf(patchPerson{}))
+	if err != nil {
+		t.Errorf("Generate() error = %v", err)
+		return
+	}
+	b, _ := Generate(reflect.TypeOf(patchPerson{}))
+	if !bytes.Equal(a, b) {
+		t.Errorf("Generate() = %s, expected %s", b, a)
+	}
+}
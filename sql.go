@@ -0,0 +1,176 @@
+package jsontype
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// Scan implements the sql.Scanner interface.
+func (nb *NullBool) Scan(src any) error {
+	var v sql.NullBool
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	nb.Bool, nb.Valid, nb.Present = v.Bool, v.Valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nb NullBool) Value() (driver.Value, error) {
+	if !nb.Present || !nb.Valid {
+		return nil, nil
+	}
+	return nb.Bool, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (nf *NullFloat64) Scan(src any) error {
+	var v sql.NullFloat64
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	nf.Float64, nf.Valid, nf.Present = v.Float64, v.Valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nf NullFloat64) Value() (driver.Value, error) {
+	if !nf.Present || !nf.Valid {
+		return nil, nil
+	}
+	return nf.Float64, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (ni *NullInt) Scan(src any) error {
+	var v sql.NullInt64
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	if int64(int(v.Int64)) != v.Int64 {
+		return fmt.Errorf("jsontype: converting driver.Value type %T (%d) to int: value out of range", src, v.Int64)
+	}
+	ni.Int, ni.Valid, ni.Present = int(v.Int64), v.Valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ni NullInt) Value() (driver.Value, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (ns *NullString) Scan(src any) error {
+	var v sql.NullString
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	ns.String, ns.Valid, ns.Present = v.String, v.Valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ns NullString) Value() (driver.Value, error) {
+	if !ns.Present || !ns.Valid {
+		return nil, nil
+	}
+	return ns.String, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (nt *NullTime) Scan(src any) error {
+	var v sql.NullTime
+	if err := v.Scan(src); err != nil {
+		return err
+	}
+	nt.Time, nt.Valid, nt.Present = v.Time, v.Valid, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nt NullTime) Value() (driver.Value, error) {
+	if !nt.Present || !nt.Valid {
+		return nil, nil
+	}
+	return nt.Time, nil
+}
+
+// Scan implements the sql.Scanner interface. If *T implements sql.Scanner, non-NULL values are
+// scanned by T itself; otherwise the standard database/sql conversions are used.
+func (nt *Null[T]) Scan(src any) error {
+	if src == nil {
+		var zero T
+		nt.Value, nt.Valid, nt.Present = zero, false, true
+		return nil
+	}
+	if s, ok := any(&nt.Value).(sql.Scanner); ok {
+		if err := s.Scan(src); err != nil {
+			return err
+		}
+	} else {
+		v, _, err := scan[T](src)
+		if err != nil {
+			return err
+		}
+		nt.Value = v
+	}
+	nt.Valid, nt.Present = true, true
+	return nil
+}
+
+// Valuer returns a driver.Valuer for nt. Null cannot implement driver.Valuer itself because its
+// Value field would clash with the Value method, so pass nt.Valuer() as a query argument instead.
+// If T implements driver.Valuer, the value is obtained from T; otherwise the standard driver
+// conversions are used.
+func (nt Null[T]) Valuer() driver.Valuer {
+	return nullValuer[T]{nt}
+}
+
+type nullValuer[T any] struct {
+	nt Null[T]
+}
+
+// Value implements the driver.Valuer interface.
+func (v nullValuer[T]) Value() (driver.Value, error) {
+	if !v.nt.Present || !v.nt.Valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v.nt.Value)
+}
+
+// scan converts the database value src to a T with the conversions of Rows.Scan, and reports
+// whether src is not NULL. Numbers are parsed from the text form of src and rejected if they are
+// out of range for T.
+func scan[T any](src any) (T, bool, error) {
+	var v T
+	if src == nil {
+		return v, false, nil
+	}
+	if b, ok := src.([]byte); ok {
+		src = append([]byte(nil), b...)
+	}
+	dst := reflect.ValueOf(&v).Elem()
+	if sv := reflect.ValueOf(src); sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return v, true, nil
+	}
+	var s sql.NullString
+	if err := s.Scan(src); err != nil {
+		return v, false, err
+	}
+	if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 {
+		dst.SetBytes([]byte(s.String))
+		return v, true, nil
+	}
+	if err := typeinfo.ParseText(dst, s.String); err != nil {
+		return v, false, fmt.Errorf("jsontype: converting driver.Value type %T (%q) to %s: %w", src, s.String, dst.Type(), err)
+	}
+	return v, true, nil
+}
@@ -0,0 +1,263 @@
+package jsontype
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// echoDriver is a fake database/sql driver whose queries return a single row containing
+// the query arguments.
+type echoDriver struct{}
+
+func (echoDriver) Open(string) (driver.Conn, error) { return echoConn{}, nil }
+
+type echoConn struct{}
+
+func (echoConn) Prepare(string) (driver.Stmt, error) { return echoStmt{}, nil }
+func (echoConn) Close() error                        { return nil }
+func (echoConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type echoStmt struct{}
+
+func (echoStmt) Close() error                               { return nil }
+func (echoStmt) NumInput() int                              { return -1 }
+func (echoStmt) Exec([]driver.Value) (driver.Result, error) { return nil, errors.New("not supported") }
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{values: args}, nil
+}
+
+type echoRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *echoRows) Columns() []string {
+	columns := make([]string, len(r.values))
+	for i := range columns {
+		columns[i] = "c" + strconv.Itoa(i)
+	}
+	return columns
+}
+
+func (r *echoRows) Close() error { return nil }
+
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	copy(dest, r.values)
+	r.done = true
+	return nil
+}
+
+func init() {
+	sql.Register("jsontype-echo", echoDriver{})
+}
+
+func openEchoDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("jsontype-echo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// upperString is a string type implementing sql.Scanner and driver.Valuer.
+type upperString string
+
+func (s *upperString) Scan(src any) error {
+	v, ok := src.(string)
+	if !ok {
+		return errors.New("not a string")
+	}
+	*s = upperString(v + "!")
+	return nil
+}
+
+func (s upperString) Value() (driver.Value, error) {
+	return string(s) + "?", nil
+}
+
+// Test the Scan and Value methods of the Null types
+func TestSQL_RoundTrip(t *testing.T) {
+	tm := time.Date(2024, 01, 01, 00, 00, 00, 00, time.UTC)
+	tests := []struct {
+		name     string
+		arg      any
+		dest     any
+		expected any
+	}{
+		{
+			name:     "NullBool valid",
+			arg:      NullBool{Bool: true, Valid: true, Present: true},
+			dest:     &NullBool{},
+			expected: &NullBool{Bool: true, Valid: true, Present: true},
+		},
+		{
+			name:     "NullBool null",
+			arg:      NullBool{Present: true},
+			dest:     &NullBool{Bool: true, Valid: true},
+			expected: &NullBool{Present: true},
+		},
+		{
+			name:     "NullFloat64 valid",
+			arg:      NullFloat64{Float64: 4.56, Valid: true, Present: true},
+			dest:     &NullFloat64{},
+			expected: &NullFloat64{Float64: 4.56, Valid: true, Present: true},
+		},
+		{
+			name:     "NullInt valid",
+			arg:      NullInt{Int: 123, Valid: true, Present: true},
+			dest:     &NullInt{},
+			expected: &NullInt{Int: 123, Valid: true, Present: true},
+		},
+		{
+			name:     "NullInt absent",
+			arg:      NullInt{Int: 123, Valid: true},
+			dest:     &NullInt{},
+			expected: &NullInt{Present: true},
+		},
+		{
+			name:     "NullInt8 valid",
+			arg:      NullInt8{Int8: -128, Valid: true, Present: true},
+			dest:     &NullInt8{},
+			expected: &NullInt8{Int8: -128, Valid: true, Present: true},
+		},
+		{
+			name:     "NullInt64 valid",
+			arg:      NullInt64{Int64: 9223372036854775807, Valid: true, Present: true},
+			dest:     &NullInt64{},
+			expected: &NullInt64{Int64: 9223372036854775807, Valid: true, Present: true},
+		},
+		{
+			name:     "NullUint16 null",
+			arg:      NullUint16{Present: true},
+			dest:     &NullUint16{Uint16: 1, Valid: true},
+			expected: &NullUint16{Present: true},
+		},
+		{
+			name:     "NullUint64 valid",
+			arg:      NullUint64{Uint64: 123, Valid: true, Present: true},
+			dest:     &NullUint64{},
+			expected: &NullUint64{Uint64: 123, Valid: true, Present: true},
+		},
+		{
+			name:     "NullUint64 from string",
+			arg:      "18446744073709551615",
+			dest:     &NullUint64{},
+			expected: &NullUint64{Uint64: 18446744073709551615, Valid: true, Present: true},
+		},
+		{
+			name:     "NullString valid",
+			arg:      NullString{String: "hello", Valid: true, Present: true},
+			dest:     &NullString{},
+			expected: &NullString{String: "hello", Valid: true, Present: true},
+		},
+		{
+			name:     "NullString from int",
+			arg:      int64(123),
+			dest:     &NullString{},
+			expected: &NullString{String: "123", Valid: true, Present: true},
+		},
+		{
+			name:     "NullTime valid",
+			arg:      NullTime{Time: tm, Valid: true, Present: true},
+			dest:     &NullTime{},
+			expected: &NullTime{Time: tm, Valid: true, Present: true},
+		},
+		{
+			name:     "Null[int] valid",
+			arg:      Null[int]{Value: 123, Valid: true, Present: true}.Valuer(),
+			dest:     &Null[int]{},
+			expected: &Null[int]{Value: 123, Valid: true, Present: true},
+		},
+		{
+			name:     "Null[int] null",
+			arg:      Null[int]{Present: true}.Valuer(),
+			dest:     &Null[int]{Value: 1, Valid: true},
+			expected: &Null[int]{Present: true},
+		},
+		{
+			name:     "Null[string] from bytes",
+			arg:      []byte("hello"),
+			dest:     &Null[string]{},
+			expected: &Null[string]{Value: "hello", Valid: true, Present: true},
+		},
+		{
+			name:     "Null[bool] from int",
+			arg:      int64(1),
+			dest:     &Null[bool]{},
+			expected: &Null[bool]{Value: true, Valid: true, Present: true},
+		},
+		{
+			name:     "Null[[]byte] from string",
+			arg:      "hello",
+			dest:     &Null[[]byte]{},
+			expected: &Null[[]byte]{Value: []byte("hello"), Valid: true, Present: true},
+		},
+		{
+			name:     "Null[T] with Scanner and Valuer",
+			arg:      Null[upperString]{Value: "hello", Valid: true, Present: true}.Valuer(),
+			dest:     &Null[upperString]{},
+			expected: &Null[upperString]{Value: "hello?!", Valid: true, Present: true},
+		},
+	}
+
+	db := openEchoDB(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := db.QueryRow("echo", tt.arg).Scan(tt.dest); err != nil {
+				t.Errorf("Scan() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(tt.dest, tt.expected) {
+				t.Errorf("Scan() = %+v, expected %+v", tt.dest, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the Scan methods with values that cannot be converted
+func TestSQL_ScanError(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  any
+		dest any
+	}{
+		{name: "NullBool", arg: "hello", dest: &NullBool{}},
+		{name: "NullFloat64", arg: "hello", dest: &NullFloat64{}},
+		{name: "NullInt", arg: 4.56, dest: &NullInt{}},
+		{name: "NullInt8 out of range", arg: int64(128), dest: &NullInt8{}},
+		{name: "NullUint8 negative", arg: int64(-1), dest: &NullUint8{}},
+		{name: "NullUint32 out of range", arg: int64(4294967296), dest: &NullUint32{}},
+		{name: "NullUint64 out of range", arg: "18446744073709551616", dest: &NullUint64{}},
+		{name: "NullTime", arg: int64(123), dest: &NullTime{}},
+		{name: "Null[int]", arg: "hello", dest: &Null[int]{}},
+		{name: "Null[T] with Scanner", arg: int64(123), dest: &Null[upperString]{}},
+	}
+
+	db := openEchoDB(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := db.QueryRow("echo", tt.arg).Scan(tt.dest); err == nil {
+				t.Errorf("Scan() error = nil, expected an error")
+			}
+		})
+	}
+}
+
+// Test the Value method of NullUint64 with values that do not fit in an int64
+func TestNullUint64_ValueError(t *testing.T) {
+	nu := NullUint64{Uint64: 1 << 63, Valid: true, Present: true}
+	if _, err := nu.Value(); err == nil {
+		t.Errorf("Value() error = nil, expected an error")
+	}
+}
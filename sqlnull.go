@@ -0,0 +1,28 @@
+//go:build go1.22
+
+package jsontype
+
+import "database/sql"
+
+// The functions in this file convert from and to the generic sql.Null[T], which was added in Go 1.22.
+
+// FromSQL returns a value of the Null type N holding a T that is set to the value of s, or null if
+// s is not valid:
+//
+//	name := jsontype.FromSQL[jsontype.NullString](sql.Null[string]{V: "John", Valid: true})
+//
+// The sql.NullString and other sql.Null* types are converted by FromSQLString and the other
+// FromSQL* functions, which do not require Go 1.22.
+func FromSQL[N any, T any, PN interface {
+	*N
+	Set(T)
+	SetNull()
+}](s sql.Null[T]) N {
+	return fromSQL[N, T, PN](s.V, s.Valid)
+}
+
+// ToSQL returns n as a sql.Null[T], which is not valid if n is null or absent.
+func ToSQL[T any](n Nullable[T]) sql.Null[T] {
+	v, ok := n.Get()
+	return sql.Null[T]{V: v, Valid: ok}
+}
@@ -0,0 +1,36 @@
+//go:build go1.22
+
+package jsontype
+
+import (
+	"database/sql"
+	"testing"
+)
+
+// Test the FromSQL and ToSQL functions
+func TestFromSQLToSQL(t *testing.T) {
+	if result := FromSQL[NullInt64](sql.Null[int64]{V: 42, Valid: true}); result != (NullInt64{Int64: 42, Valid: true, Present: true}) {
+		t.Errorf("FromSQL() = %+v, expected 42", result)
+	}
+	if result := FromSQL[Null[float64]](sql.Null[float64]{V: 1.5}); result != NullValue[float64]() {
+		t.Errorf("FromSQL() = %+v, expected null", result)
+	}
+
+	tests := []struct {
+		name     string
+		input    Nullable[uint16]
+		expected sql.Null[uint16]
+	}{
+		{name: "Set", input: NullUint16{Uint16: 16, Valid: true, Present: true}, expected: sql.Null[uint16]{V: 16, Valid: true}},
+		{name: "Null", input: NullValue[uint16](), expected: sql.Null[uint16]{}},
+		{name: "Absent", input: NullUint16{}, expected: sql.Null[uint16]{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ToSQL(tt.input); result != tt.expected {
+				t.Errorf("ToSQL() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
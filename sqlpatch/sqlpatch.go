@@ -0,0 +1,82 @@
+// Package sqlpatch builds SQL UPDATE statements from structs containing the Null types of package
+// jsontype, such as a decoded PATCH request body. Only the columns of present fields are updated
+// and present null fields set their column to NULL.
+//
+// Column names are taken from the db struct tag, or the Go field name if the tag is missing.
+// Fields tagged with db:"-" and fields that are not Null types are ignored. Table and column
+// names are written to the statement as is, so they must not come from untrusted input.
+package sqlpatch
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// Placeholder is the style of the argument placeholders in a statement.
+type Placeholder int
+
+const (
+	Question Placeholder = iota // Question uses ? placeholders, e.g. for MySQL and SQLite.
+	Dollar                      // Dollar uses $1, $2, ... placeholders, e.g. for PostgreSQL.
+	AtP                         // AtP uses @p1, @p2, ... placeholders, e.g. for SQL Server.
+)
+
+// format returns the placeholder for the n-th argument, starting at 1.
+func (p Placeholder) format(n int) string {
+	switch p {
+	case Dollar:
+		return "$" + strconv.Itoa(n)
+	case AtP:
+		return "@p" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
+// ErrEmptyPatch is returned by Update if the patch has no present fields.
+var ErrEmptyPatch = errors.New("sqlpatch: patch has no present fields")
+
+// Key identifies the row to update by the value of a column.
+type Key struct {
+	Column string
+	Value  any
+}
+
+// Update returns an UPDATE statement for table that sets the columns of the present fields of
+// patch on the row identified by key, together with its arguments. Patch must be a struct or a
+// pointer to a struct. If no field of patch is present, Update returns ErrEmptyPatch.
+func Update(table string, key Key, patch any, ph Placeholder) (query string, args []any, err error) {
+	v := reflect.ValueOf(patch)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("sqlpatch: patch must be a struct or a pointer to a struct, got %T", patch)
+	}
+
+	var sets []string
+	for _, f := range typeinfo.Fields(v.Type(), "db") {
+		fv, _ := typeinfo.FieldByIndex(v, f.Index)
+		value, valid, present, ok := typeinfo.NullParts(fv)
+		if !ok || !present.Bool() {
+			continue
+		}
+		if !valid.Bool() {
+			sets = append(sets, f.Name+" = NULL")
+			continue
+		}
+		args = append(args, value.Interface())
+		sets = append(sets, f.Name+" = "+ph.format(len(args)))
+	}
+	if len(sets) == 0 {
+		return "", nil, ErrEmptyPatch
+	}
+	args = append(args, key.Value)
+	query = "UPDATE " + table + " SET " + strings.Join(sets, ", ") + " WHERE " + key.Column + " = " + ph.format(len(args))
+	return query, args, nil
+}
@@ -0,0 +1,105 @@
+package sqlpatch
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mbe81/jsontype"
+)
+
+type personPatch struct {
+	FirstName jsontype.NullString `db:"first_name"`
+	LastName  jsontype.NullString `db:"last_name"`
+	Age       jsontype.Null[int]  `db:"age"`
+	Birthday  jsontype.NullTime   `db:"birthday"`
+	Internal  jsontype.NullString `db:"-"`
+	Nickname  jsontype.NullString
+	Ignored   string `db:"ignored"`
+}
+
+// Test the Update function
+func TestUpdate(t *testing.T) {
+	birthday := time.Date(2000, 01, 01, 00, 00, 00, 00, time.UTC)
+	tests := []struct {
+		name          string
+		patch         any
+		ph            Placeholder
+		expectedQuery string
+		expectedArgs  []any
+		expectedErr   error
+	}{
+		{
+			name: "Question placeholders",
+			patch: personPatch{
+				FirstName: jsontype.NullString{String: "John", Valid: true, Present: true},
+				LastName:  jsontype.NullString{Present: true},
+				Age:       jsontype.Null[int]{Value: 42, Valid: true, Present: true},
+			},
+			ph:            Question,
+			expectedQuery: "UPDATE person SET first_name = ?, last_name = NULL, age = ? WHERE id = ?",
+			expectedArgs:  []any{"John", 42, 7},
+		},
+		{
+			name: "Dollar placeholders",
+			patch: &personPatch{
+				LastName: jsontype.NullString{Present: true},
+				Birthday: jsontype.NullTime{Time: birthday, Valid: true, Present: true},
+				Nickname: jsontype.NullString{String: "Johnny", Valid: true, Present: true},
+			},
+			ph:            Dollar,
+			expectedQuery: "UPDATE person SET last_name = NULL, birthday = $1, Nickname = $2 WHERE id = $3",
+			expectedArgs:  []any{birthday, "Johnny", 7},
+		},
+		{
+			name: "AtP placeholders",
+			patch: personPatch{
+				Age: jsontype.Null[int]{Value: 42, Valid: true, Present: true},
+			},
+			ph:            AtP,
+			expectedQuery: "UPDATE person SET age = @p1 WHERE id = @p2",
+			expectedArgs:  []any{42, 7},
+		},
+		{
+			name: "Only null fields",
+			patch: personPatch{
+				Age: jsontype.Null[int]{Present: true},
+			},
+			ph:            Dollar,
+			expectedQuery: "UPDATE person SET age = NULL WHERE id = $1",
+			expectedArgs:  []any{7},
+		},
+		{
+			name: "No present fields",
+			patch: personPatch{
+				Internal: jsontype.NullString{String: "secret", Valid: true, Present: true},
+				Ignored:  "ignored",
+			},
+			expectedErr: ErrEmptyPatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := Update("person", Key{Column: "id", Value: 7}, tt.patch, tt.ph)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Update() error = %v, expected %v", err, tt.expectedErr)
+				return
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("Update() query = %s, expected %s", query, tt.expectedQuery)
+			}
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("Update() args = %v, expected %v", args, tt.expectedArgs)
+			}
+		})
+	}
+}
+
+// Test the Update function with an invalid patch
+func TestUpdate_InvalidPatch(t *testing.T) {
+	if _, _, err := Update("person", Key{Column: "id", Value: 7}, "hello", Question); err == nil {
+		t.Errorf("Update() error = nil, expected an error")
+	}
+}
@@ -0,0 +1,177 @@
+package jsontype
+
+import (
+	"strconv"
+	"time"
+)
+
+// State is the state of a value of one of the Null types: absent, null or set.
+type State int
+
+// The states of the Null types. They are prefixed with State, as Null is the generic Null type.
+const (
+	StateAbsent State = iota // StateAbsent means the value is absent (Present is false)
+	StateNull                // StateNull means the value is present and null (Valid is false)
+	StateSet                 // StateSet means the value is present and valid
+)
+
+// String returns "absent", "null" or "set".
+func (s State) String() string {
+	switch s {
+	case StateAbsent:
+		return "absent"
+	case StateNull:
+		return "null"
+	case StateSet:
+		return "set"
+	}
+	return "State(" + strconv.Itoa(int(s)) + ")"
+}
+
+// stateOf returns the State of a value with the given Valid and Present fields. A value that is
+// not present is absent, also if Valid is set.
+func stateOf(valid, present bool) State {
+	switch {
+	case !present:
+		return StateAbsent
+	case !valid:
+		return StateNull
+	}
+	return StateSet
+}
+
+// Value returns a Null that is set to v.
+func Value[T any](v T) Null[T] {
+	return Null[T]{Value: v, Valid: true, Present: true}
+}
+
+// NullValue returns a Null that is present and null.
+func NullValue[T any]() Null[T] {
+	return Null[T]{Present: true}
+}
+
+// Absent returns a Null that is absent.
+func Absent[T any]() Null[T] {
+	return Null[T]{}
+}
+
+// State returns the state of nb.
+func (nb NullBool) State() State {
+	return stateOf(nb.Valid, nb.Present)
+}
+
+// Set sets nb to v.
+func (nb *NullBool) Set(v bool) {
+	nb.Bool, nb.Valid, nb.Present = v, true, true
+}
+
+// SetNull sets nb to null.
+func (nb *NullBool) SetNull() {
+	nb.Bool, nb.Valid, nb.Present = false, false, true
+}
+
+// Unset makes nb absent.
+func (nb *NullBool) Unset() {
+	*nb = NullBool{}
+}
+
+// State returns the state of nf.
+func (nf NullFloat64) State() State {
+	return stateOf(nf.Valid, nf.Present)
+}
+
+// Set sets nf to v.
+func (nf *NullFloat64) Set(v float64) {
+	nf.Float64, nf.Valid, nf.Present = v, true, true
+}
+
+// SetNull sets nf to null.
+func (nf *NullFloat64) SetNull() {
+	nf.Float64, nf.Valid, nf.Present = 0, false, true
+}
+
+// Unset makes nf absent.
+func (nf *NullFloat64) Unset() {
+	*nf = NullFloat64{}
+}
+
+// State returns the state of ni.
+func (ni NullInt) State() State {
+	return stateOf(ni.Valid, ni.Present)
+}
+
+// Set sets ni to v.
+func (ni *NullInt) Set(v int) {
+	ni.Int, ni.Valid, ni.Present = v, true, true
+}
+
+// SetNull sets ni to null.
+func (ni *NullInt) SetNull() {
+	ni.Int, ni.Valid, ni.Present = 0, false, true
+}
+
+// Unset makes ni absent.
+func (ni *NullInt) Unset() {
+	*ni = NullInt{}
+}
+
+// State returns the state of ns.
+func (ns NullString) State() State {
+	return stateOf(ns.Valid, ns.Present)
+}
+
+// Set sets ns to v.
+func (ns *NullString) Set(v string) {
+	ns.String, ns.Valid, ns.Present = v, true, true
+}
+
+// SetNull sets ns to null.
+func (ns *NullString) SetNull() {
+	ns.String, ns.Valid, ns.Present = "", false, true
+}
+
+// Unset makes ns absent.
+func (ns *NullString) Unset() {
+	*ns = NullString{}
+}
+
+// State returns the state of nt.
+func (nt NullTime) State() State {
+	return stateOf(nt.Valid, nt.Present)
+}
+
+// Set sets nt to v.
+func (nt *NullTime) Set(v time.Time) {
+	nt.Time, nt.Valid, nt.Present = v, true, true
+}
+
+// SetNull sets nt to null.
+func (nt *NullTime) SetNull() {
+	nt.Time, nt.Valid, nt.Present = time.Time{}, false, true
+}
+
+// Unset makes nt absent.
+func (nt *NullTime) Unset() {
+	*nt = NullTime{}
+}
+
+// State returns the state of nt.
+func (nt Null[T]) State() State {
+	return stateOf(nt.Valid, nt.Present)
+}
+
+// Set sets nt to v.
+func (nt *Null[T]) Set(v T) {
+	nt.Value, nt.Valid, nt.Present = v, true, true
+}
+
+// SetNull sets nt to null.
+func (nt *Null[T]) SetNull() {
+	var zero T
+	nt.Value, nt.Valid, nt.Present = zero, false, true
+}
+
+// Unset makes nt absent.
+func (nt *Null[T]) Unset() {
+	*nt = Null[T]{}
+}
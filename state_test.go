@@ -0,0 +1,110 @@
+package jsontype
+
+import (
+	"testing"
+	"time"
+)
+
+// Test the State method of the Null types
+func TestState(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{ State() State }
+		expected State
+	}{
+		{name: "Absent", input: NullBool{}, expected: StateAbsent},
+		{name: "Null", input: NullBool{Present: true}, expected: StateNull},
+		{name: "Set", input: NullBool{Bool: true, Valid: true, Present: true}, expected: StateSet},
+		{name: "Set to zero value", input: NullInt{Valid: true, Present: true}, expected: StateSet},
+		{name: "Valid but not present", input: NullString{String: "foo", Valid: true}, expected: StateAbsent},
+		{name: "NullUint32 null", input: NullUint32{Present: true}, expected: StateNull},
+		{name: "Null[T] set", input: Value(1.5), expected: StateSet},
+		{name: "Null[T] null", input: NullValue[string](), expected: StateNull},
+		{name: "Null[T] absent", input: Absent[time.Time](), expected: StateAbsent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.input.State(); result != tt.expected {
+				t.Errorf("State() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the String method of State
+func TestState_String(t *testing.T) {
+	tests := []struct {
+		input    State
+		expected string
+	}{
+		{input: StateAbsent, expected: "absent"},
+		{input: StateNull, expected: "null"},
+		{input: StateSet, expected: "set"},
+		{input: State(5), expected: "State(5)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if result := tt.input.String(); result != tt.expected {
+				t.Errorf("String() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the Set, SetNull and Unset methods of the Null types
+func TestSetSetNullUnset(t *testing.T) {
+	var ni NullInt
+	ni.Set(42)
+	if ni != (NullInt{Int: 42, Valid: true, Present: true}) {
+		t.Errorf("Set() = %+v, expected set to 42", ni)
+	}
+	ni.SetNull()
+	if ni != (NullInt{Present: true}) {
+		t.Errorf("SetNull() = %+v, expected null", ni)
+	}
+	ni.Unset()
+	if ni != (NullInt{}) {
+		t.Errorf("Unset() = %+v, expected absent", ni)
+	}
+
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	var nt NullTime
+	nt.Set(ts)
+	if nt.State() != StateSet || !nt.Time.Equal(ts) {
+		t.Errorf("Set() = %+v, expected set to %v", nt, ts)
+	}
+	nt.SetNull()
+	if nt != (NullTime{Present: true}) {
+		t.Errorf("SetNull() = %+v, expected null", nt)
+	}
+
+	n := Value([]int{1})
+	n.SetNull()
+	if n.Value != nil || n.State() != StateNull {
+		t.Errorf("SetNull() = %+v, expected null", n)
+	}
+	n.Set([]int{2})
+	n.Unset()
+	if n.Value != nil || n.State() != StateAbsent {
+		t.Errorf("Unset() = %+v, expected absent", n)
+	}
+}
+
+// Test that the constructors marshal as expected
+func TestConstructors_Marshal(t *testing.T) {
+	v := struct {
+		A Null[int]    `json:"a"`
+		B Null[int]    `json:"b"`
+		C Null[string] `json:"c"`
+	}{A: Value(1), B: NullValue[int](), C: Absent[string]()}
+	b, err := Marshal(v)
+	if err != nil {
+		t.Errorf("Marshal() error = %v", err)
+		return
+	}
+	if expected := `{"a":1,"b":null}`; string(b) != expected {
+		t.Errorf("Marshal() = %s, expected %s", b, expected)
+	}
+}
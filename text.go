@@ -0,0 +1,174 @@
+package jsontype
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// NullText is the text representation of null used by the MarshalText and UnmarshalText methods of
+// the types in this package. MarshalText returns NullText for null and absent values, and UnmarshalText
+// sets a present null value if the text equals NullText. Every successful call to UnmarshalText sets
+// Present, so an empty parameter in a query string is present, and for NullString it is a valid empty
+// string unless NullText is empty.
+//
+// The text NullText cannot be represented as a value: a NullString holding it is marshaled as
+// NullText and unmarshaled as null. With the default, ?q=null is always null, never the string
+// "null"; set NullText to a text that cannot occur as a value if the difference matters.
+//
+// NullText defaults to "null". It may be changed, for example to "" or `\N`, during program
+// initialization; it must not be changed concurrently with marshaling or unmarshaling.
+var NullText = "null"
+
+// unmarshalQuoted sets u from the JSON string data with its UnmarshalText method, so that the
+// boolean and number types can be used as map keys, which encoding/json decodes with UnmarshalJSON.
+// It reports whether data is a string that u accepts.
+func unmarshalQuoted(data []byte, u encoding.TextUnmarshaler) bool {
+	if len(data) == 0 || data[0] != '"' {
+		return false
+	}
+	s, ok := parseString(data)
+	if !ok && json.Unmarshal(data, &s) != nil {
+		return false
+	}
+	return u.UnmarshalText([]byte(s)) == nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (nb *NullBool) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		nb.Bool, nb.Valid, nb.Present = false, false, true
+		return nil
+	}
+	b, err := strconv.ParseBool(string(text))
+	if err != nil {
+		return err
+	}
+	nb.Bool, nb.Valid, nb.Present = b, true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (nb NullBool) MarshalText() ([]byte, error) {
+	if !nb.Present || !nb.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatBool(nb.Bool)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (nf *NullFloat64) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		nf.Float64, nf.Valid, nf.Present = 0, false, true
+		return nil
+	}
+	f, err := strconv.ParseFloat(string(text), 64)
+	if err != nil {
+		return err
+	}
+	nf.Float64, nf.Valid, nf.Present = f, true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (nf NullFloat64) MarshalText() ([]byte, error) {
+	if !nf.Present || !nf.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatFloat(nf.Float64, 'g', -1, 64)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ni *NullInt) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		ni.Int, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	n, err := strconv.ParseInt(string(text), 10, 0)
+	if err != nil {
+		return err
+	}
+	ni.Int, ni.Valid, ni.Present = int(n), true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ni NullInt) MarshalText() ([]byte, error) {
+	if !ni.Present || !ni.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(strconv.FormatInt(int64(ni.Int), 10)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ns *NullString) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		ns.String, ns.Valid, ns.Present = "", false, true
+		return nil
+	}
+	ns.String, ns.Valid, ns.Present = string(text), true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (ns NullString) MarshalText() ([]byte, error) {
+	if !ns.Present || !ns.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(ns.String), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The time must be in RFC 3339 format.
+func (nt *NullTime) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		nt.Time, nt.Valid, nt.Present = time.Time{}, false, true
+		return nil
+	}
+	if err := nt.Time.UnmarshalText(text); err != nil {
+		return err
+	}
+	nt.Valid, nt.Present = true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The time is formatted in RFC 3339 format.
+func (nt NullTime) MarshalText() ([]byte, error) {
+	if !nt.Present || !nt.Valid {
+		return []byte(NullText), nil
+	}
+	return nt.Time.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. If *T implements
+// encoding.TextUnmarshaler, the text is unmarshaled by T itself; otherwise T must be a string, bool,
+// integer or floating-point type, which is parsed with package strconv.
+func (nt *Null[T]) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		var zero T
+		nt.Value, nt.Valid, nt.Present = zero, false, true
+		return nil
+	}
+	var v T
+	if err := typeinfo.ParseText(reflect.ValueOf(&v).Elem(), string(text)); err != nil {
+		return err
+	}
+	nt.Value, nt.Valid, nt.Present = v, true, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. If T implements encoding.TextMarshaler,
+// the text is marshaled by T itself; otherwise T must be a string, bool, integer or floating-point type.
+func (nt Null[T]) MarshalText() ([]byte, error) {
+	if !nt.Present || !nt.Valid {
+		return []byte(NullText), nil
+	}
+	s, err := typeinfo.FormatText(reflect.ValueOf(&nt.Value).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
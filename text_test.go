@@ -0,0 +1,160 @@
+package jsontype
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test the UnmarshalText method of the Null types
+func TestUnmarshalText(t *testing.T) {
+	tests := []struct {
+		name      string
+		dst       interface{ UnmarshalText([]byte) error }
+		text      string
+		expected  any
+		expectErr bool
+	}{
+		{name: "NullBool value", dst: &NullBool{}, text: "true", expected: NullBool{Bool: true, Valid: true, Present: true}},
+		{name: "NullBool null", dst: &NullBool{Bool: true, Valid: true}, text: "null", expected: NullBool{Present: true}},
+		{name: "NullBool invalid", dst: &NullBool{}, text: "yes", expected: NullBool{}, expectErr: true},
+		{name: "NullFloat64 value", dst: &NullFloat64{}, text: "1.5", expected: NullFloat64{Float64: 1.5, Valid: true, Present: true}},
+		{name: "NullInt value", dst: &NullInt{}, text: "-42", expected: NullInt{Int: -42, Valid: true, Present: true}},
+		{name: "NullInt empty", dst: &NullInt{}, text: "", expected: NullInt{}, expectErr: true},
+		{name: "NullInt8 out of range", dst: &NullInt8{}, text: "128", expected: NullInt8{}, expectErr: true},
+		{name: "NullInt64 value", dst: &NullInt64{}, text: "9223372036854775807", expected: NullInt64{Int64: 9223372036854775807, Valid: true, Present: true}},
+		{name: "NullUint8 value", dst: &NullUint8{}, text: "255", expected: NullUint8{Uint8: 255, Valid: true, Present: true}},
+		{name: "NullUint negative", dst: &NullUint{}, text: "-1", expected: NullUint{}, expectErr: true},
+		{name: "NullString value", dst: &NullString{}, text: "foo", expected: NullString{String: "foo", Valid: true, Present: true}},
+		{name: "NullString empty", dst: &NullString{}, text: "", expected: NullString{Valid: true, Present: true}},
+		{name: "NullString null", dst: &NullString{}, text: "null", expected: NullString{Present: true}},
+		{name: "NullTime value", dst: &NullTime{}, text: "2023-01-02T03:04:05Z", expected: NullTime{Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true, Present: true}},
+		{name: "NullTime invalid", dst: &NullTime{}, text: "2023-01-02", expected: NullTime{}, expectErr: true},
+		{name: "Null[int] value", dst: &Null[int]{}, text: "7", expected: Null[int]{Value: 7, Valid: true, Present: true}},
+		{name: "Null[int] null", dst: &Null[int]{}, text: "null", expected: Null[int]{Present: true}},
+		{name: "Null[string] value", dst: &Null[string]{}, text: "bar", expected: Null[string]{Value: "bar", Valid: true, Present: true}},
+		{name: "Null[float32] value", dst: &Null[float32]{}, text: "0.25", expected: Null[float32]{Value: 0.25, Valid: true, Present: true}},
+		{name: "Null[time.Time] value", dst: &Null[time.Time]{}, text: "2023-01-02T03:04:05Z", expected: Null[time.Time]{Value: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true, Present: true}},
+		{name: "Null[[]int] unsupported", dst: &Null[[]int]{}, text: "1", expected: Null[[]int]{}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dst.UnmarshalText([]byte(tt.text))
+			if (err != nil) != tt.expectErr {
+				t.Errorf("UnmarshalText() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if result := reflect.ValueOf(tt.dst).Elem().Interface(); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("UnmarshalText() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the MarshalText method of the Null types
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      interface{ MarshalText() ([]byte, error) }
+		expected string
+	}{
+		{name: "NullBool value", src: NullBool{Bool: true, Valid: true, Present: true}, expected: "true"},
+		{name: "NullBool null", src: NullBool{Present: true}, expected: "null"},
+		{name: "NullBool absent", src: NullBool{}, expected: "null"},
+		{name: "NullFloat64 value", src: NullFloat64{Float64: 1.5, Valid: true, Present: true}, expected: "1.5"},
+		{name: "NullFloat64 large", src: NullFloat64{Float64: 1e21, Valid: true, Present: true}, expected: "1e+21"},
+		{name: "NullInt value", src: NullInt{Int: -42, Valid: true, Present: true}, expected: "-42"},
+		{name: "NullInt16 value", src: NullInt16{Int16: -300, Valid: true, Present: true}, expected: "-300"},
+		{name: "NullUint64 value", src: NullUint64{Uint64: 18446744073709551615, Valid: true, Present: true}, expected: "18446744073709551615"},
+		{name: "NullString value", src: NullString{String: "foo", Valid: true, Present: true}, expected: "foo"},
+		{name: "NullTime value", src: NullTime{Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true, Present: true}, expected: "2023-01-02T03:04:05Z"},
+		{name: "Null[int] value", src: Null[int]{Value: 7, Valid: true, Present: true}, expected: "7"},
+		{name: "Null[bool] null", src: Null[bool]{Present: true}, expected: "null"},
+		{name: "Null[time.Time] value", src: Null[time.Time]{Value: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true, Present: true}, expected: "2023-01-02T03:04:05Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.src.MarshalText()
+			if err != nil {
+				t.Errorf("MarshalText() error = %v", err)
+				return
+			}
+			if string(result) != tt.expected {
+				t.Errorf("MarshalText() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+// Test a changed NullText
+func TestNullText(t *testing.T) {
+	defer func(s string) { NullText = s }(NullText)
+	NullText = ""
+
+	var ns NullString
+	if err := ns.UnmarshalText([]byte("")); err != nil || ns != (NullString{Present: true}) {
+		t.Errorf("UnmarshalText() = %+v, %v, expected present null", ns, err)
+	}
+	if err := ns.UnmarshalText([]byte("null")); err != nil || ns != (NullString{String: "null", Valid: true, Present: true}) {
+		t.Errorf("UnmarshalText() = %+v, %v, expected value null", ns, err)
+	}
+	if b, _ := (NullInt{}).MarshalText(); string(b) != "" {
+		t.Errorf("MarshalText() = %q, expected empty text", b)
+	}
+}
+
+// Test the boolean and number types as JSON object keys
+func TestText_MapKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		result   func() any
+		expected string
+	}{
+		{
+			name:     "NullInt",
+			input:    map[NullInt]string{{Int: 1, Valid: true, Present: true}: "one", {Present: true}: "none"},
+			result:   func() any { return &map[NullInt]string{} },
+			expected: `{"1":"one","null":"none"}`,
+		},
+		{
+			name:     "NullBool",
+			input:    map[NullBool]int{{Bool: true, Valid: true, Present: true}: 1, {Present: true}: 0},
+			result:   func() any { return &map[NullBool]int{} },
+			expected: `{"null":0,"true":1}`,
+		},
+		{
+			name:     "NullFloat64",
+			input:    map[NullFloat64]int{{Float64: 1.5, Valid: true, Present: true}: 1},
+			result:   func() any { return &map[NullFloat64]int{} },
+			expected: `{"1.5":1}`,
+		},
+		{
+			name:     "NullUint8",
+			input:    map[NullUint8]int{{Uint8: 255, Valid: true, Present: true}: 1},
+			result:   func() any { return &map[NullUint8]int{} },
+			expected: `{"255":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.input)
+			if err != nil || string(b) != tt.expected {
+				t.Errorf("json.Marshal() = %s, %v, expected %s", b, err, tt.expected)
+				return
+			}
+			result := tt.result()
+			if err := json.Unmarshal(b, result); err != nil {
+				t.Errorf("json.Unmarshal() error = %v", err)
+				return
+			}
+			if got := reflect.ValueOf(result).Elem().Interface(); !reflect.DeepEqual(got, tt.input) {
+				t.Errorf("json.Unmarshal() = %v, expected %v", got, tt.input)
+			}
+		})
+	}
+}
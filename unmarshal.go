@@ -0,0 +1,304 @@
+package jsontype
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// UnmarshalOption configures Unmarshal.
+type UnmarshalOption func(*unmarshalOptions)
+
+type unmarshalOptions struct {
+	stopAtFirstError      bool
+	disallowUnknownFields bool
+}
+
+// StopAtFirstError makes Unmarshal stop at the first value that cannot be decoded and return its
+// error, like json.Unmarshal, instead of collecting the errors of all values.
+func StopAtFirstError() UnmarshalOption {
+	return func(o *unmarshalOptions) { o.stopAtFirstError = true }
+}
+
+// DisallowUnknownFields makes Unmarshal report object members that do not match a struct field as
+// an *UnknownFieldError, like json.Decoder.DisallowUnknownFields.
+func DisallowUnknownFields() UnmarshalOption {
+	return func(o *unmarshalOptions) { o.disallowUnknownFields = true }
+}
+
+// Unmarshal parses the JSON-encoded data and stores the result in the value pointed to by v, like
+// json.Unmarshal, but reports every value that cannot be decoded as a *DecodeError with the JSON
+// Pointer (RFC 6901) to the value in its Path, so that the offending fields can be reported to a
+// client at once.
+//
+// Unmarshal keeps decoding after a value fails. A Null field that cannot be decoded is left absent,
+// and other values that cannot be decoded keep their previous value. Unmarshal returns the errors
+// of all failed values as an Errors list, or only the first error with the StopAtFirstError option.
+//
+// Unmarshal walks JSON objects into structs and maps with string keys, and JSON arrays into slices
+// and arrays, and decodes all other values with json.Unmarshal. Values of types implementing
+// json.Unmarshaler or encoding.TextUnmarshaler are decoded by their own methods, except for Null
+// types wrapping structs, slices, arrays or maps, which are walked as well. Struct fields are
+// matched as by json.Unmarshal, and unknown object members are ignored unless the
+// DisallowUnknownFields option is given.
+//
+// Syntax errors are returned as the *json.SyntaxError reported by json.Unmarshal, before anything
+// is stored in v.
+func Unmarshal(data []byte, v any, opts ...UnmarshalOption) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	if !json.Valid(data) {
+		return json.Unmarshal(data, v)
+	}
+	var d valueDecoder
+	for _, opt := range opts {
+		opt(&d.opts)
+	}
+	if err := d.decodeValue(bytes.TrimSpace(data), rv.Elem(), ""); err != nil {
+		return err
+	}
+	if len(d.errs) > 0 {
+		return d.errs
+	}
+	return nil
+}
+
+// valueDecoder decodes JSON values into Go values, collecting the errors.
+type valueDecoder struct {
+	opts unmarshalOptions
+	errs Errors
+}
+
+// fail records err. It returns err if decoding stops at the first error, and nil otherwise.
+func (d *valueDecoder) fail(err error) error {
+	if d.opts.stopAtFirstError {
+		return err
+	}
+	d.errs = append(d.errs, err)
+	return nil
+}
+
+// decodeValue decodes the valid JSON value data into v, which must be settable.
+func (d *valueDecoder) decodeValue(data []byte, v reflect.Value, path string) error {
+	isNull := bytes.Equal(data, nullLiteral)
+	if value, valid, present, ok := typeinfo.NullParts(v); ok {
+		if isNull || !walks(value.Type(), data) {
+			if err := v.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+				v.Set(reflect.Zero(v.Type()))
+				return d.fail(withPath(err, data, v.Type(), path))
+			}
+			return nil
+		}
+		n := len(d.errs)
+		err := d.decodeValue(data, value, path)
+		if err != nil || len(d.errs) > n {
+			v.Set(reflect.Zero(v.Type()))
+			return err
+		}
+		valid.SetBool(true)
+		present.SetBool(true)
+		return nil
+	}
+	if isNull || !walks(v.Type(), data) {
+		return d.decodeJSON(data, v, path)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decodeValue(data, v.Elem(), path)
+	case reflect.Struct:
+		return d.decodeStruct(data, v, path)
+	case reflect.Map:
+		return d.decodeMap(data, v, path)
+	default:
+		return d.decodeArray(data, v, path)
+	}
+}
+
+// decodeJSON decodes data into v with json.Unmarshal, restoring the previous value of v if it fails.
+func (d *valueDecoder) decodeJSON(data []byte, v reflect.Value, path string) error {
+	old := reflect.New(v.Type()).Elem()
+	old.Set(v)
+	if err := json.Unmarshal(data, v.Addr().Interface()); err != nil {
+		v.Set(old)
+		return d.fail(withPath(err, data, v.Type(), path))
+	}
+	return nil
+}
+
+// withPath returns err as a *DecodeError for the value data of type t at path.
+func withPath(err error, data []byte, t reflect.Type, path string) error {
+	if err == nil {
+		return nil
+	}
+	if de, ok := err.(*DecodeError); ok {
+		if de.Path == "" {
+			de.Path = path
+		}
+		return de
+	}
+	expected := jsonKind(t)
+	if typeinfo.IsNull(t) {
+		expected = jsonKind(t.Field(0).Type)
+	}
+	return &DecodeError{Path: path, Expected: expected, Value: append([]byte(nil), data...), Nullable: isNullable(t), Err: err}
+}
+
+func (d *valueDecoder) decodeStruct(data []byte, v reflect.Value, path string) error {
+	fields := typeinfo.Fields(v.Type(), "json")
+	return eachMember(data, func(name string, raw []byte) error {
+		fpath := appendPointer(path, name)
+		f, ok := lookupField(fields, name)
+		if !ok {
+			if d.opts.disallowUnknownFields {
+				return d.fail(&UnknownFieldError{Path: fpath})
+			}
+			return nil
+		}
+		fv, ok := typeinfo.SettableFieldByIndex(v, f.Index)
+		if !ok {
+			err := fmt.Errorf("cannot set embedded pointer to unexported struct in %s", v.Type())
+			return d.fail(withPath(err, raw, f.StructField.Type, fpath))
+		}
+		if f.HasOption("string") && isQuotable(fv.Type()) && !bytes.Equal(raw, nullLiteral) {
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return d.fail(withPath(err, raw, fv.Type(), fpath))
+			}
+			return d.decodeJSON([]byte(s), fv, fpath)
+		}
+		return d.decodeValue(raw, fv, fpath)
+	})
+}
+
+func (d *valueDecoder) decodeMap(data []byte, v reflect.Value, path string) error {
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	return eachMember(data, func(name string, raw []byte) error {
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := d.decodeValue(raw, elem, appendPointer(path, name)); err != nil {
+			return err
+		}
+		v.SetMapIndex(reflect.ValueOf(name).Convert(v.Type().Key()), elem)
+		return nil
+	})
+}
+
+func (d *valueDecoder) decodeArray(data []byte, v reflect.Value, path string) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if v.Kind() == reflect.Slice {
+		v.Set(reflect.MakeSlice(v.Type(), len(elems), len(elems)))
+	}
+	for i := 0; i < v.Len(); i++ {
+		if i >= len(elems) {
+			v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+			continue
+		}
+		if err := d.decodeValue(elems[i], v.Index(i), appendPointer(path, strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eachMember calls f with the name and value of every member of the JSON object data, in order.
+func eachMember(data []byte, f func(name string, raw []byte) error) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		if err := f(tok.(string), raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupField returns the field named name, preferring an exact match over a case-insensitive
+// match like json.Unmarshal.
+func lookupField(fields []typeinfo.Field, name string) (typeinfo.Field, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return typeinfo.Field{}, false
+}
+
+// implementsUnmarshaler reports whether pointers to values of type t implement json.Unmarshaler or
+// encoding.TextUnmarshaler.
+func implementsUnmarshaler(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType)
+}
+
+// walks reports whether Unmarshal walks into a value of type t to decode data, rather than decoding
+// data with json.Unmarshal.
+func walks(t reflect.Type, data []byte) bool {
+	if implementsUnmarshaler(t) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return data[0] == '{'
+	case reflect.Map:
+		return data[0] == '{' && t.Key().Kind() == reflect.String && !implementsUnmarshaler(t.Key())
+	case reflect.Slice, reflect.Array:
+		return data[0] == '['
+	case reflect.Pointer:
+		return walks(t.Elem(), data)
+	}
+	return false
+}
+
+// isNullable reports whether null is a meaningful value for values of type t.
+func isNullable(t reflect.Type) bool {
+	if typeinfo.IsNull(t) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// isQuotable reports whether values of type t are quoted by the string option of the json tag.
+func isQuotable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
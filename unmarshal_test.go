@@ -0,0 +1,386 @@
+package jsontype
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type unmarshalAddress struct {
+	Street NullString `json:"street"`
+	Zip    NullUint16 `json:"zip"`
+}
+
+type unmarshalPerson struct {
+	Name      NullString                  `json:"name"`
+	Age       NullInt8                    `json:"age"`
+	Count     int                         `json:"count,string"`
+	Address   *unmarshalAddress           `json:"address"`
+	Previous  Null[unmarshalAddress]      `json:"previous"`
+	Phones    []NullString                `json:"phones"`
+	Labels    map[string]NullBool         `json:"labels"`
+	Scores    Null[[]NullFloat64]         `json:"scores"`
+	Raw       json.RawMessage             `json:"raw"`
+	Nested    map[string]unmarshalAddress `json:"a/b"`
+	Untouched string                      `json:"untouched"`
+}
+
+// Test the Unmarshal function
+func TestUnmarshal(t *testing.T) {
+	input := `{
+		"name": "John",
+		"age": null,
+		"count": "3",
+		"address": {"street": "Main Street"},
+		"previous": {"zip": 1234},
+		"phones": ["123", null],
+		"labels": {"a": true},
+		"scores": [1.5],
+		"raw": {"x": 1},
+		"unknown": [1, 2],
+		"UNTOUCHED": "set"
+	}`
+	expected := unmarshalPerson{
+		Name:      NullString{String: "John", Valid: true, Present: true},
+		Age:       NullInt8{Present: true},
+		Count:     3,
+		Address:   &unmarshalAddress{Street: NullString{String: "Main Street", Valid: true, Present: true}},
+		Previous:  Null[unmarshalAddress]{Value: unmarshalAddress{Zip: NullUint16{Uint16: 1234, Valid: true, Present: true}}, Valid: true, Present: true},
+		Phones:    []NullString{{String: "123", Valid: true, Present: true}, {Present: true}},
+		Labels:    map[string]NullBool{"a": {Bool: true, Valid: true, Present: true}},
+		Scores:    Null[[]NullFloat64]{Value: []NullFloat64{{Float64: 1.5, Valid: true, Present: true}}, Valid: true, Present: true},
+		Raw:       json.RawMessage(`{"x": 1}`),
+		Untouched: "set",
+	}
+
+	var result unmarshalPerson
+	if err := Unmarshal([]byte(input), &result); err != nil {
+		t.Errorf("Unmarshal() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Unmarshal() = %+v, expected %+v", result, expected)
+	}
+
+	// json.Unmarshal gives the same result.
+	var std unmarshalPerson
+	if err := json.Unmarshal([]byte(input), &std); err != nil {
+		t.Errorf("json.Unmarshal() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(result, std) {
+		t.Errorf("Unmarshal() = %+v, json.Unmarshal() = %+v", result, std)
+	}
+}
+
+// Test the DecodeError returned by the Unmarshal function
+func TestUnmarshal_DecodeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected DecodeError
+		message  string
+	}{
+		{
+			name:     "Null type",
+			input:    `{"name":1}`,
+			expected: DecodeError{Path: "/name", Expected: "string", Value: []byte(`1`), Nullable: true},
+			message:  "jsontype: cannot unmarshal 1 at /name: expected string or null: json: cannot unmarshal number into Go value of type string",
+		},
+		{
+			name:     "Out of range",
+			input:    `{"age":200}`,
+			expected: DecodeError{Path: "/age", Expected: "integer", Value: []byte(`200`), Nullable: true},
+			message:  "jsontype: cannot unmarshal 200 at /age: expected integer or null: out of range for int8, maximum is 127",
+		},
+		{
+			name:     "Nested struct",
+			input:    `{"address":{"zip":"x1234"}}`,
+			expected: DecodeError{Path: "/address/zip", Expected: "integer", Value: []byte(`"x1234"`), Nullable: true},
+		},
+		{
+			name:     "Null struct",
+			input:    `{"previous":{"zip":-1}}`,
+			expected: DecodeError{Path: "/previous/zip", Expected: "integer", Value: []byte(`-1`), Nullable: true},
+		},
+		{
+			name:     "Null struct of wrong kind",
+			input:    `{"previous":[]}`,
+			expected: DecodeError{Path: "/previous", Expected: "object", Value: []byte(`[]`), Nullable: true},
+		},
+		{
+			name:     "Slice element",
+			input:    `{"phones":["1",2]}`,
+			expected: DecodeError{Path: "/phones/1", Expected: "string", Value: []byte(`2`), Nullable: true},
+		},
+		{
+			name:     "Map value",
+			input:    `{"labels":{"x/y":"yes"}}`,
+			expected: DecodeError{Path: "/labels/x~1y", Expected: "boolean", Value: []byte(`"yes"`), Nullable: true},
+		},
+		{
+			name:     "Escaped field name",
+			input:    `{"a/b":{"k":{"street":false}}}`,
+			expected: DecodeError{Path: "/a~1b/k/street", Expected: "string", Value: []byte(`false`), Nullable: true},
+		},
+		{
+			name:     "Plain field",
+			input:    `{"untouched":12}`,
+			expected: DecodeError{Path: "/untouched", Expected: "string", Value: []byte(`12`)},
+			message:  "jsontype: cannot unmarshal 12 at /untouched: expected string: json: cannot unmarshal number into Go value of type string",
+		},
+		{
+			name:     "String option",
+			input:    `{"count":"x"}`,
+			expected: DecodeError{Path: "/count", Expected: "integer", Value: []byte(`x`)},
+		},
+		{
+			name:     "Wrong kind for slice",
+			input:    `{"phones":{}}`,
+			expected: DecodeError{Path: "/phones", Expected: "array", Value: []byte(`{}`), Nullable: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p unmarshalPerson
+			err := Unmarshal([]byte(tt.input), &p)
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Errorf("Unmarshal() error = %v, expected a *DecodeError", err)
+				return
+			}
+			if de.Path != tt.expected.Path || de.Expected != tt.expected.Expected || string(de.Value) != string(tt.expected.Value) ||
+				de.Nullable != tt.expected.Nullable || de.Err == nil {
+				t.Errorf("Unmarshal() error = %#v, expected %#v", de, tt.expected)
+			}
+			if tt.message != "" && err.Error() != tt.message {
+				t.Errorf("Unmarshal() error = %s, expected %s", err, tt.message)
+			}
+		})
+	}
+}
+
+// Test that the UnmarshalJSON methods return a DecodeError
+func TestUnmarshalJSON_DecodeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst      json.Unmarshaler
+		input    string
+		expected string
+	}{
+		{name: "NullBool", dst: &NullBool{}, input: `1`, expected: "boolean"},
+		{name: "NullFloat64", dst: &NullFloat64{}, input: `"x"`, expected: "number"},
+		{name: "NullInt", dst: &NullInt{}, input: `1.5`, expected: "integer"},
+		{name: "NullUint", dst: &NullUint{}, input: `true`, expected: "integer"},
+		{name: "NullString", dst: &NullString{}, input: `{}`, expected: "string"},
+		{name: "NullTime", dst: &NullTime{}, input: `"yesterday"`, expected: "string"},
+		{name: "Null[[]int]", dst: &Null[[]int]{}, input: `{}`, expected: "array"},
+		{name: "Null[map[string]int]", dst: &Null[map[string]int]{}, input: `[]`, expected: "object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dst.UnmarshalJSON([]byte(tt.input))
+			var de *DecodeError
+			if !errors.As(err, &de) || de.Expected != tt.expected || string(de.Value) != tt.input || !de.Nullable || de.Path != "" {
+				t.Errorf("UnmarshalJSON() error = %#v, expected a *DecodeError expecting %s", err, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that Unmarshal collects the errors of all values that cannot be decoded
+func TestUnmarshal_CollectErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     []UnmarshalOption
+		expected []string
+	}{
+		{
+			name:     "All errors",
+			input:    `{"name":1,"age":"x","address":{"street":"Main","zip":-1},"untouched":false}`,
+			expected: []string{"/name", "/age", "/address/zip", "/untouched"},
+		},
+		{
+			name:     "Stop at first error",
+			input:    `{"name":1,"age":"x"}`,
+			opts:     []UnmarshalOption{StopAtFirstError()},
+			expected: []string{"/name"},
+		},
+		{
+			name:     "Unknown fields ignored",
+			input:    `{"nickname":"J","age":"x"}`,
+			expected: []string{"/age"},
+		},
+		{
+			name:     "Unknown fields disallowed",
+			input:    `{"nickname":"J","age":"x","address":{"city":"X"}}`,
+			opts:     []UnmarshalOption{DisallowUnknownFields()},
+			expected: []string{"/nickname", "/age", "/address/city"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p unmarshalPerson
+			err := Unmarshal([]byte(tt.input), &p, tt.opts...)
+			errs := []error{err}
+			if list, ok := err.(Errors); ok {
+				errs = list
+			}
+			var paths []string
+			for _, err := range errs {
+				var de *DecodeError
+				var ue *UnknownFieldError
+				switch {
+				case errors.As(err, &de):
+					paths = append(paths, de.Path)
+				case errors.As(err, &ue):
+					paths = append(paths, ue.Path)
+				default:
+					t.Errorf("Unmarshal() error = %v, expected a *DecodeError or *UnknownFieldError", err)
+				}
+			}
+			if !reflect.DeepEqual(paths, tt.expected) {
+				t.Errorf("Unmarshal() error paths = %v, expected %v", paths, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that Unmarshal leaves failed Null fields absent and keeps decoding the other fields
+func TestUnmarshal_FailedFields(t *testing.T) {
+	p := unmarshalPerson{Age: NullInt8{Int8: 5, Valid: true, Present: true}, Untouched: "keep"}
+	err := Unmarshal([]byte(`{"age":300,"name":"John","untouched":1,"address":{"zip":"x","street":"Main"}}`), &p)
+	if _, ok := err.(Errors); !ok || len(err.(Errors)) != 3 {
+		t.Fatalf("Unmarshal() error = %v, expected 3 errors", err)
+	}
+	if p.Age.State() != StateAbsent {
+		t.Errorf("Age = %+v, expected absent", p.Age)
+	}
+	if p.Name != (NullString{String: "John", Valid: true, Present: true}) {
+		t.Errorf("Name = %+v, expected John", p.Name)
+	}
+	if p.Untouched != "keep" {
+		t.Errorf("Untouched = %q, expected keep", p.Untouched)
+	}
+	expected := &unmarshalAddress{Street: NullString{String: "Main", Valid: true, Present: true}}
+	if !reflect.DeepEqual(p.Address, expected) {
+		t.Errorf("Address = %+v, expected %+v", p.Address, expected)
+	}
+}
+
+// Test that walked Null fields whose elements or members fail are left absent
+func TestUnmarshal_FailedNullFields(t *testing.T) {
+	type ids struct {
+		IDs      Null[[]int]            `json:"ids"`
+		Previous Null[unmarshalAddress] `json:"previous"`
+	}
+	tests := []struct {
+		name     string
+		input    string
+		opts     []UnmarshalOption
+		dst      ids
+		expected ids
+	}{
+		{
+			name:     "Null slice",
+			input:    `{"ids":[1,"x"]}`,
+			dst:      ids{IDs: Null[[]int]{Value: []int{7}, Valid: true, Present: true}},
+			expected: ids{},
+		},
+		{
+			name:     "Null struct",
+			input:    `{"ids":[1],"previous":{"street":"Main","zip":"x"}}`,
+			expected: ids{IDs: Null[[]int]{Value: []int{1}, Valid: true, Present: true}},
+		},
+		{
+			name:     "Null slice, stop at first error",
+			input:    `{"ids":[1,"x"]}`,
+			opts:     []UnmarshalOption{StopAtFirstError()},
+			dst:      ids{IDs: Null[[]int]{Value: []int{7}, Valid: true, Present: true}},
+			expected: ids{},
+		},
+		{
+			name:     "Null struct, stop at first error",
+			input:    `{"previous":{"street":"Main","zip":"x"}}`,
+			opts:     []UnmarshalOption{StopAtFirstError()},
+			expected: ids{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.dst
+			if err := Unmarshal([]byte(tt.input), &v, tt.opts...); err == nil {
+				t.Fatalf("Unmarshal() error = nil, expected an error")
+			}
+			if !reflect.DeepEqual(v, tt.expected) {
+				t.Errorf("Unmarshal() = %+v, expected %+v", v, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the message of an UnknownFieldError
+func TestUnknownFieldError_Error(t *testing.T) {
+	err := &UnknownFieldError{Path: "/address/city"}
+	if expected := "jsontype: unknown field /address/city"; err.Error() != expected {
+		t.Errorf("Error() = %s, expected %s", err, expected)
+	}
+}
+
+// Test the errors of Unmarshal that are not DecodeErrors
+func TestUnmarshal_Errors(t *testing.T) {
+	var p unmarshalPerson
+	var se *json.SyntaxError
+	if err := Unmarshal([]byte(`{"name":`), &p); !errors.As(err, &se) {
+		t.Errorf("Unmarshal() error = %v, expected a *json.SyntaxError", err)
+	}
+	var ie *json.InvalidUnmarshalError
+	if err := Unmarshal([]byte(`{}`), p); !errors.As(err, &ie) {
+		t.Errorf("Unmarshal() error = %v, expected a *json.InvalidUnmarshalError", err)
+	}
+}
+
+// Test that the message of a DecodeError truncates long values and leaves out unknown values
+func TestDecodeError_Error(t *testing.T) {
+	err := &DecodeError{Expected: "string", Value: []byte(`[` + strings.Repeat("1,", 30) + `1]`), Err: errors.New("bad")}
+	expected := "jsontype: cannot unmarshal [1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1...: expected string: bad"
+	if err.Error() != expected {
+		t.Errorf("Error() = %s, expected %s", err, expected)
+	}
+
+	err = &DecodeError{Path: "/name", Expected: "string", Nullable: true, Err: errors.New("bad")}
+	if expected := "jsontype: cannot unmarshal value at /name: expected string or null: bad"; err.Error() != expected {
+		t.Errorf("Error() = %s, expected %s", err, expected)
+	}
+}
+
+// Test that Unmarshal allocates nil embedded pointers to structs
+func TestUnmarshal_EmbeddedPointer(t *testing.T) {
+	var u marshalEmbedded
+	if err := Unmarshal([]byte(`{"id":8,"name":"John"}`), &u); err != nil {
+		t.Errorf("Unmarshal() error = %v", err)
+	} else if u.MarshalBase == nil || u.ID.Int != 8 || u.Name.String != "John" {
+		t.Errorf("Unmarshal() = %+v, expected an allocated MarshalBase with ID 8", u)
+	}
+}
+
+// Test that Unmarshal sets the field hiding an embedded field with the same name, like json.Unmarshal
+func TestUnmarshal_ShadowedField(t *testing.T) {
+	var p, expected marshalShadowed
+	if err := Unmarshal([]byte(`{"id":5,"version":2}`), &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"id":5,"version":2}`), &expected); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if p != expected || p.ID.Int != 5 || p.MarshalBase.ID.Present {
+		t.Errorf("Unmarshal() = %+v, expected %+v", p, expected)
+	}
+}
@@ -0,0 +1,107 @@
+package jsontype
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// The validation rules of the jsontype struct tag.
+const (
+	ruleRequired  = "required"  // the field must be present
+	ruleNotNull   = "notnull"   // the field must not be null if it is present
+	ruleForbidden = "forbidden" // the field must not be present
+)
+
+// Validate checks the presence and nullability of the Null fields of v against the rules in their
+// jsontype struct tag, for example:
+//
+//	type CreatePerson struct {
+//		Name jsontype.NullString `json:"name" jsontype:"required,notnull"`
+//		ID   jsontype.NullInt    `json:"id" jsontype:"forbidden"`
+//	}
+//
+// The rule "required" requires the field to be present, "notnull" requires it not to be null if it
+// is present, and "forbidden" requires it to be absent. Structs, pointers to structs, slices and
+// arrays of structs and valid Null values of struct types are validated recursively.
+//
+// Validate returns nil if v is valid, and otherwise an Errors list with a *ValidationError for every
+// violated rule, identifying the field by its JSON Pointer. Rules on fields that are not Null types
+// and unknown rules are reported as a single error of another type.
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("jsontype: Validate requires a struct or a pointer to a struct, got %T", v)
+	}
+	var errs Errors
+	if err := validateValue(rv, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateValue(v reflect.Value, path string, errs *Errors) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return validateValue(v.Elem(), path, errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(v.Index(i), appendPointer(path, strconv.Itoa(i)), errs); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	if value, valid, _, ok := typeinfo.NullParts(v); ok {
+		if valid.Bool() {
+			return validateValue(value, path, errs)
+		}
+		return nil
+	}
+	for _, f := range typeinfo.Fields(v.Type(), "json") {
+		fv, _ := typeinfo.FieldByIndex(v, f.Index)
+		fpath := appendPointer(path, f.Name)
+		if tag := f.StructField.Tag.Get("jsontype"); tag != "" {
+			_, valid, present, ok := typeinfo.NullParts(fv)
+			if !ok {
+				return fmt.Errorf("jsontype: jsontype tag on %s, which is not a Null type", fpath)
+			}
+			for _, rule := range strings.Split(tag, ",") {
+				var violated bool
+				switch rule = strings.TrimSpace(rule); rule {
+				case ruleRequired:
+					violated = !present.Bool()
+				case ruleNotNull:
+					violated = present.Bool() && !valid.Bool()
+				case ruleForbidden:
+					violated = present.Bool()
+				default:
+					return fmt.Errorf("jsontype: unknown rule %q in jsontype tag of %s", rule, fpath)
+				}
+				if violated {
+					*errs = append(*errs, &ValidationError{Path: fpath, Rule: rule})
+				}
+			}
+		}
+		if err := validateValue(fv, fpath, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package jsontype
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type validateAddress struct {
+	City NullString `json:"city" jsontype:"notnull"`
+}
+
+type validatePerson struct {
+	ID        NullInt               `json:"id" jsontype:"forbidden"`
+	Name      NullString            `json:"name" jsontype:"required,notnull"`
+	Nickname  NullString            `json:"nickname"`
+	Address   *validateAddress      `json:"address"`
+	Contacts  []validateAddress     `json:"contacts"`
+	Secondary Null[validateAddress] `json:"secondary"`
+}
+
+// Test the Validate function
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected []error
+	}{
+		{
+			name:  "Valid",
+			input: validatePerson{Name: NullString{String: "John", Valid: true, Present: true}},
+		},
+		{
+			name:  "Required and forbidden",
+			input: &validatePerson{ID: NullInt{Int: 1, Valid: true, Present: true}},
+			expected: []error{
+				&ValidationError{Path: "/id", Rule: "forbidden"},
+				&ValidationError{Path: "/name", Rule: "required"},
+			},
+		},
+		{
+			name:  "Not null",
+			input: validatePerson{Name: NullString{Present: true}},
+			expected: []error{
+				&ValidationError{Path: "/name", Rule: "notnull"},
+			},
+		},
+		{
+			name: "Nested",
+			input: validatePerson{
+				Name:      NullString{String: "John", Valid: true, Present: true},
+				Address:   &validateAddress{City: NullString{Present: true}},
+				Contacts:  []validateAddress{{}, {City: NullString{Present: true}}},
+				Secondary: Null[validateAddress]{Value: validateAddress{City: NullString{Present: true}}, Valid: true, Present: true},
+			},
+			expected: []error{
+				&ValidationError{Path: "/address/city", Rule: "notnull"},
+				&ValidationError{Path: "/contacts/1/city", Rule: "notnull"},
+				&ValidationError{Path: "/secondary/city", Rule: "notnull"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.input)
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v, expected nil", err)
+				}
+				return
+			}
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Errorf("Validate() error = %v, expected Errors", err)
+				return
+			}
+			if !reflect.DeepEqual([]error(errs), tt.expected) {
+				t.Errorf("Validate() = %v, expected %v", errs, Errors(tt.expected))
+			}
+		})
+	}
+}
+
+// Test the Validate function with invalid input and tags
+func TestValidate_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+	}{
+		{name: "Not a struct", input: 123},
+		{name: "Unknown rule", input: struct {
+			Name NullString `jsontype:"mandatory"`
+		}{}},
+		{name: "Not a Null type", input: struct {
+			Name string `jsontype:"required"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.input)
+			var errs Errors
+			if err == nil || errors.As(err, &errs) {
+				t.Errorf("Validate() error = %v, expected a non-validation error", err)
+			}
+		})
+	}
+}
+
+// Test the Error method of Errors and ValidationError
+func TestErrors_Error(t *testing.T) {
+	err := Errors{
+		&ValidationError{Path: "/id", Rule: "forbidden"},
+		&ValidationError{Path: "/name", Rule: "required"},
+		&ValidationError{Path: "/city", Rule: "notnull"},
+	}
+	expected := "jsontype: /id is not allowed; jsontype: /name is required; jsontype: /city must not be null"
+	if err.Error() != expected {
+		t.Errorf("Error() = %s, expected %s", err.Error(), expected)
+	}
+	var ve *ValidationError
+	if !errors.As(err, &ve) || ve.Path != "/id" {
+		t.Errorf("errors.As() = %v, expected the first ValidationError", ve)
+	}
+}
@@ -0,0 +1,209 @@
+package jsontype
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/mbe81/jsontype/internal/typeinfo"
+)
+
+// The types in this package implement the Unmarshaler and Marshaler interfaces of gopkg.in/yaml.v2,
+// which need no import: a missing key leaves a field untouched and any other value sets it to a
+// valid value. MarshalYAML returns nil for null and absent values. Absent fields are omitted by
+// fields tagged with omitempty, as the YAML encoders use the IsZero method.
+//
+// Note that gopkg.in/yaml.v2 and gopkg.in/yaml.v3 do not call UnmarshalYAML for null nodes, but set
+// the field to its zero value, so with those packages alone ~ and null leave a field absent. Decode
+// with DecodeYAML to make them a present null.
+
+// DecodeYAML calls decode, typically yaml.Unmarshal of gopkg.in/yaml.v2 or v3 with the document
+// bound, to decode into the struct pointed to by v, so that ~ and null set Null fields to a present
+// null, while a missing key leaves a field untouched.
+//
+// As the YAML decoders set the fields of null nodes to their zero value, which is absent, without
+// calling UnmarshalYAML, DecodeYAML first marks the Null fields of v, and of the structs in the
+// fields of v, as valid but absent, a state the decoders never leave a field in. After decode
+// returns, fields that are still marked get their previous value back and fields that were set to
+// their zero value are set to a present null. Null fields in structs behind pointers, slices or
+// maps are not marked, so that ~ and null leave those absent.
+func DecodeYAML(v any, decode func(any) error) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("jsontype: DecodeYAML requires a non-nil pointer to a struct, got %T", v)
+	}
+	marks := markNullFields(nil, rv.Elem())
+	err := decode(v)
+	for _, m := range marks {
+		_, valid, present, _ := typeinfo.NullParts(m.field)
+		switch {
+		case present.Bool():
+		case valid.Bool():
+			m.field.Set(m.old)
+		default:
+			present.SetBool(true)
+		}
+	}
+	return err
+}
+
+// yamlMark is a Null field marked by DecodeYAML, with its value before decoding.
+type yamlMark struct {
+	field, old reflect.Value
+}
+
+// markNullFields marks the Null fields of the struct v and of the structs in its fields as valid but
+// absent, and appends them to marks.
+func markNullFields(marks []yamlMark, v reflect.Value) []yamlMark {
+	for _, f := range typeinfo.Fields(v.Type(), "yaml") {
+		fv, ok := typeinfo.FieldByIndex(v, f.Index)
+		if !ok {
+			continue
+		}
+		if _, valid, _, ok := typeinfo.NullParts(fv); ok {
+			old := reflect.New(fv.Type()).Elem()
+			old.Set(fv)
+			fv.Set(reflect.Zero(fv.Type()))
+			valid.SetBool(true)
+			marks = append(marks, yamlMark{field: fv, old: old})
+			continue
+		}
+		if _, ok := reflect.PointerTo(fv.Type()).MethodByName("UnmarshalYAML"); fv.Kind() == reflect.Struct && !ok {
+			marks = markNullFields(marks, fv)
+		}
+	}
+	return marks
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (nb *NullBool) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *bool
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		nb.Bool, nb.Valid, nb.Present = false, false, true
+		return nil
+	}
+	nb.Bool, nb.Valid, nb.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (nb NullBool) MarshalYAML() (any, error) {
+	if !nb.Present || !nb.Valid {
+		return nil, nil
+	}
+	return nb.Bool, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (nf *NullFloat64) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *float64
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		nf.Float64, nf.Valid, nf.Present = 0, false, true
+		return nil
+	}
+	nf.Float64, nf.Valid, nf.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (nf NullFloat64) MarshalYAML() (any, error) {
+	if !nf.Present || !nf.Valid {
+		return nil, nil
+	}
+	return nf.Float64, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (ni *NullInt) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *int
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		ni.Int, ni.Valid, ni.Present = 0, false, true
+		return nil
+	}
+	ni.Int, ni.Valid, ni.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (ni NullInt) MarshalYAML() (any, error) {
+	if !ni.Present || !ni.Valid {
+		return nil, nil
+	}
+	return ni.Int, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (ns *NullString) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		ns.String, ns.Valid, ns.Present = "", false, true
+		return nil
+	}
+	ns.String, ns.Valid, ns.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (ns NullString) MarshalYAML() (any, error) {
+	if !ns.Present || !ns.Valid {
+		return nil, nil
+	}
+	return ns.String, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (nt *NullTime) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *time.Time
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		nt.Time, nt.Valid, nt.Present = time.Time{}, false, true
+		return nil
+	}
+	nt.Time, nt.Valid, nt.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (nt NullTime) MarshalYAML() (any, error) {
+	if !nt.Present || !nt.Valid {
+		return nil, nil
+	}
+	return nt.Time, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2.
+func (nt *Null[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var v *T
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		var zero T
+		nt.Value, nt.Valid, nt.Present = zero, false, true
+		return nil
+	}
+	nt.Value, nt.Valid, nt.Present = *v, true, true
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2.
+func (nt Null[T]) MarshalYAML() (any, error) {
+	if !nt.Present || !nt.Valid {
+		return nil, nil
+	}
+	return nt.Value, nil
+}
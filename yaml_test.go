@@ -0,0 +1,245 @@
+package jsontype
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// yamlUnmarshaler is implemented by the types in this package.
+type yamlUnmarshaler interface {
+	UnmarshalYAML(func(any) error) error
+}
+
+// unmarshalYAML is a small stand-in for a YAML decoder. It decodes a document of "key: scalar" lines
+// into the fields of the struct pointed to by v, which are matched by their yaml tag, as
+// gopkg.in/yaml.v2 does: it calls UnmarshalYAML for non-null nodes, and sets the field to its zero
+// value for null nodes.
+func unmarshalYAML(doc string, v any) error {
+	rv := reflect.ValueOf(v).Elem()
+	for _, line := range strings.Split(strings.TrimSpace(doc), "\n") {
+		key, scalar, ok := strings.Cut(line, ":")
+		if !ok {
+			return fmt.Errorf("invalid line %q", line)
+		}
+		key, scalar = strings.TrimSpace(key), strings.TrimSpace(scalar)
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).Tag.Get("yaml") != key {
+				continue
+			}
+			if isYAMLNull(scalar) {
+				rv.Field(i).Set(reflect.Zero(rv.Field(i).Type()))
+				continue
+			}
+			u := rv.Field(i).Addr().Interface().(yamlUnmarshaler)
+			if err := u.UnmarshalYAML(func(dst any) error { return decodeYAMLScalar(scalar, dst) }); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeYAMLScalar decodes the plain scalar s into dst, a pointer to a pointer, which is left nil
+// if s is null.
+func decodeYAMLScalar(s string, dst any) error {
+	p := reflect.ValueOf(dst).Elem()
+	if isYAMLNull(s) {
+		p.Set(reflect.Zero(p.Type()))
+		return nil
+	}
+	v := reflect.New(p.Type().Elem())
+	var err error
+	switch x := v.Interface().(type) {
+	case *string:
+		*x = s
+	case *time.Time:
+		*x, err = time.Parse(time.RFC3339, s)
+	case *bool:
+		*x, err = strconv.ParseBool(s)
+	case *float64:
+		*x, err = strconv.ParseFloat(s, 64)
+	default:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, v.Elem().Type().Bits())
+		if err == nil {
+			v.Elem().SetInt(n)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal %q into %s: %w", s, p.Type().Elem(), err)
+	}
+	p.Set(v)
+	return nil
+}
+
+// isYAMLNull reports whether the plain scalar s is null.
+func isYAMLNull(s string) bool {
+	return s == "" || s == "~" || s == "null"
+}
+
+type yamlConfig struct {
+	Name    NullString   `yaml:"name"`
+	Port    NullInt      `yaml:"port"`
+	Debug   NullBool     `yaml:"debug"`
+	Ratio   NullFloat64  `yaml:"ratio"`
+	Retries NullInt8     `yaml:"retries"`
+	Start   NullTime     `yaml:"start"`
+	Label   Null[string] `yaml:"label"`
+	Timeout Null[int64]  `yaml:"timeout"`
+}
+
+// Test the UnmarshalYAML method of the Null types
+func TestUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name      string
+		doc       string
+		expected  yamlConfig
+		expectErr bool
+	}{
+		{
+			name: "Values",
+			doc:  "name: api\nport: 8080\ndebug: true\nratio: 0.5\nretries: 3\nstart: 2023-01-02T03:04:05Z\nlabel: prod\ntimeout: 30",
+			expected: yamlConfig{
+				Name:    NullString{String: "api", Valid: true, Present: true},
+				Port:    NullInt{Int: 8080, Valid: true, Present: true},
+				Debug:   NullBool{Bool: true, Valid: true, Present: true},
+				Ratio:   NullFloat64{Float64: 0.5, Valid: true, Present: true},
+				Retries: NullInt8{Int8: 3, Valid: true, Present: true},
+				Start:   NullTime{Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true, Present: true},
+				Label:   Null[string]{Value: "prod", Valid: true, Present: true},
+				Timeout: Null[int64]{Value: 30, Valid: true, Present: true},
+			},
+		},
+		{
+			name:     "Null nodes are zeroed by the decoder",
+			doc:      "name: ~\nport: null\nlabel:",
+			expected: yamlConfig{},
+		},
+		{
+			name:      "Invalid value",
+			doc:       "retries: 300",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c yamlConfig
+			err := unmarshalYAML(tt.doc, &c)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("UnmarshalYAML() error = %v, expectErr %v", err, tt.expectErr)
+				return
+			}
+			if !tt.expectErr && !reflect.DeepEqual(c, tt.expected) {
+				t.Errorf("UnmarshalYAML() = %+v, expected %+v", c, tt.expected)
+			}
+		})
+	}
+}
+
+// Test the DecodeYAML function
+func TestDecodeYAML(t *testing.T) {
+	port := NullInt{Int: 80, Valid: true, Present: true}
+	tests := []struct {
+		name      string
+		doc       string
+		expected  yamlConfig
+		expectErr bool
+	}{
+		{
+			name: "Values, null and missing keys",
+			doc:  "name: ~\ndebug: true\nlabel:\ntimeout: null",
+			expected: yamlConfig{
+				Name:    NullString{Present: true},
+				Port:    port,
+				Debug:   NullBool{Bool: true, Valid: true, Present: true},
+				Label:   Null[string]{Present: true},
+				Timeout: Null[int64]{Present: true},
+			},
+		},
+		{
+			name:      "Invalid value",
+			doc:       "name: api\nretries: 300",
+			expected:  yamlConfig{Name: NullString{String: "api", Valid: true, Present: true}, Port: port},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := yamlConfig{Port: port}
+			err := DecodeYAML(&c, func(v any) error { return unmarshalYAML(tt.doc, v) })
+			if (err != nil) != tt.expectErr {
+				t.Errorf("DecodeYAML() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if !reflect.DeepEqual(c, tt.expected) {
+				t.Errorf("DecodeYAML() = %+v, expected %+v", c, tt.expected)
+			}
+		})
+	}
+}
+
+type yamlServer struct {
+	Config   yamlConfig  `yaml:"config"`
+	Backup   *yamlConfig `yaml:"backup"`
+	Internal NullInt     `yaml:"-"`
+}
+
+// Test that DecodeYAML marks the Null fields of nested structs, but not of structs behind pointers
+func TestDecodeYAML_Nested(t *testing.T) {
+	s := yamlServer{Internal: NullInt{Int: 1, Valid: true, Present: true}}
+	err := DecodeYAML(&s, func(v any) error {
+		// decode config.name: ~ and backup: {} like a YAML decoder
+		v.(*yamlServer).Config.Name = NullString{}
+		v.(*yamlServer).Backup = &yamlConfig{}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("DecodeYAML() error = %v", err)
+	}
+	expected := yamlServer{
+		Config:   yamlConfig{Name: NullString{Present: true}},
+		Backup:   &yamlConfig{},
+		Internal: NullInt{Int: 1, Valid: true, Present: true},
+	}
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("DecodeYAML() = %+v, expected %+v", s, expected)
+	}
+
+	if err := DecodeYAML(s, func(any) error { return nil }); err == nil {
+		t.Errorf("DecodeYAML() error = nil, expected an error for a non-pointer")
+	}
+}
+
+// Test the MarshalYAML method of the Null types
+func TestMarshalYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      interface{ MarshalYAML() (any, error) }
+		expected any
+	}{
+		{name: "NullBool value", src: NullBool{Bool: true, Valid: true, Present: true}, expected: true},
+		{name: "NullInt value", src: NullInt{Int: 42, Valid: true, Present: true}, expected: 42},
+		{name: "NullUint16 value", src: NullUint16{Uint16: 42, Valid: true, Present: true}, expected: uint16(42)},
+		{name: "NullString null", src: NullString{Present: true}, expected: nil},
+		{name: "NullString absent", src: NullString{}, expected: nil},
+		{name: "Null[string] value", src: Null[string]{Value: "foo", Valid: true, Present: true}, expected: "foo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.src.MarshalYAML()
+			if err != nil {
+				t.Errorf("MarshalYAML() error = %v", err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("MarshalYAML() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}